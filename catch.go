@@ -0,0 +1,104 @@
+package goerrors
+
+import (
+	"errors"
+	"reflect"
+)
+
+// CatchClause is a clause of a multi-clause catch block, it's made with `On` or `OnDefault`
+type CatchClause struct {
+	match   func(err IError, parents []string) int // Get the rank of the error in the hierarchy, or -1 if it's not caught
+	handler ErrorHandler                           // Catch block of the clause
+}
+
+// On makes a catch clause for the errors which have the type `T` as parent, `T` is usually a pointer type like
+// `*NotFoundError`. The handler receives the error as a `T`, which can be an embedded parent of the caught error.
+// If `T` is an interface type, the clause catches the errors which implement it, with the lowest priority.
+func On[T IError](handler func(err T) error) CatchClause {
+	errType := reflect.TypeOf((*T)(nil)).Elem()
+
+	var match func(err IError, parents []string) int
+
+	if (errType.Kind() == reflect.Ptr) && (errType.Elem().Kind() == reflect.Struct) {
+		name := errType.Elem().PkgPath() + "." + errType.Elem().Name()
+
+		match = func(err IError, parents []string) int {
+			for i, parent := range parents {
+				if parent == name {
+					return i
+				}
+			}
+
+			return -1
+		}
+	} else {
+		match = func(err IError, parents []string) int {
+			if _, ok := err.(T); ok {
+				return len(parents)
+			}
+
+			return -1
+		}
+	}
+
+	return CatchClause{
+		match: match,
+		handler: func(err IError) error {
+			var target T
+
+			if !errors.As(err, &target) {
+				target, _ = err.(T)
+			}
+
+			return handler(target)
+		},
+	}
+}
+
+// OnDefault makes the catch clause for the errors which are not caught by other clauses
+func OnDefault(handler ErrorHandler) CatchClause {
+	return CatchClause{handler: handler}
+}
+
+// Catcher makes a catch block from several catch clauses, it can be used as the catch block of `Try` or `Catch`.
+// The clause for the most specific parent of the error is used, according to the order of the error hierarchy.
+// If no clause catches the error and if there is no default clause, the error is raised again with its stack trace.
+func Catcher(clauses ...CatchClause) ErrorHandler {
+	return func(err IError) error {
+		parents := err.getParents()
+
+		var (
+			caught      ErrorHandler
+			defaultCase ErrorHandler
+			rank        = len(parents) + 1
+		)
+
+		for _, clause := range clauses {
+			if clause.match == nil {
+				defaultCase = clause.handler
+
+				continue
+			}
+
+			if r := clause.match(err, parents); (r >= 0) && (r < rank) {
+				caught, rank = clause.handler, r
+			}
+		}
+
+		if caught == nil {
+			caught = defaultCase
+		}
+
+		if caught == nil {
+			panic(err)
+		}
+
+		return caught(err)
+	}
+}
+
+// TryCatch calls the try block `try` with a multi-clause catch block made by `Catcher`, and the finally block
+// `finally` like the global function `Try`
+func TryCatch(try, finally ErrorHandler, clauses ...CatchClause) error {
+	return Try(try, Catcher(clauses...), finally)
+}
@@ -0,0 +1,148 @@
+package goerrors
+
+import (
+	"errors"
+	"testing"
+)
+
+type NotFoundError struct{ GoError }
+
+type UserNotFoundError struct{ NotFoundError }
+
+type TimeoutError struct{ GoError }
+
+func TestCatcher(t *testing.T) {
+	var called string
+
+	catch := Catcher(
+		On(func(err *NotFoundError) error {
+			called = "not found"
+
+			return nil
+		}),
+		On(func(err *UserNotFoundError) error {
+			called = "user not found"
+
+			return nil
+		}),
+		On(func(err *TimeoutError) error {
+			called = "timeout"
+
+			return nil
+		}),
+		OnDefault(func(err IError) error {
+			called = "default"
+
+			return err
+		}),
+	)
+
+	check := func(err IError, expected string) {
+		called = ""
+
+		_ = Try(func(IError) error {
+			err.Raise()
+
+			return nil
+		}, catch, nil)
+
+		if called != expected {
+			t.Errorf("The clause %q should be called instead of %q", expected, called)
+		}
+	}
+
+	notFound := &NotFoundError{}
+	userNotFound := &UserNotFoundError{}
+	timeout := &TimeoutError{}
+
+	check(notFound.Init(notFound, "not found", nil, nil, 0), "not found")
+	check(userNotFound.Init(userNotFound, "user not found", nil, nil, 0), "user not found")
+	check(timeout.Init(timeout, "timeout", nil, nil, 0), "timeout")
+	check(MakeError("other"), "default")
+
+	called = ""
+
+	if err := Try(func(IError) error {
+		panic("value")
+	}, catch, nil); (called != "default") || (err == nil) {
+		t.Error("A panic should be caught by the default clause:", called, err)
+	}
+}
+
+func TestCatcherParentValue(t *testing.T) {
+	child := &UserNotFoundError{}
+	_ = child.Init(child, "user not found", nil, nil, 0)
+
+	var caught *NotFoundError
+
+	err := TryCatch(func(IError) error {
+		child.Raise()
+
+		return nil
+	}, nil, On(func(err *NotFoundError) error {
+		caught = err
+
+		return errors.New("caught")
+	}))
+
+	if (caught == nil) || (caught.GetName() != "github.com/corebreaker/goerrors.UserNotFoundError") {
+		t.Error("The handler should receive the embedded parent error:", caught)
+	}
+
+	if (err == nil) || (err.Error() != "caught") {
+		t.Error("The error of the handler should be returned:", err)
+	}
+}
+
+func TestCatcherInterface(t *testing.T) {
+	var caught IStandardError
+
+	_ = TryCatch(func(IError) error {
+		Raise("error")
+
+		return nil
+	}, nil, On(func(err IStandardError) error {
+		caught = err
+
+		return nil
+	}))
+
+	if (caught == nil) || (caught.GetMessage() != "error") {
+		t.Error("The interface clause should catch the error:", caught)
+	}
+}
+
+func TestCatcherReraise(t *testing.T) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	timeout := &TimeoutError{}
+	_ = timeout.Init(timeout, "timeout", nil, nil, 0)
+
+	trace := timeout.StackTrace()
+
+	var reraised interface{}
+
+	func() {
+		defer func() {
+			reraised = recover()
+		}()
+
+		_ = TryCatch(func(IError) error {
+			panic(timeout)
+		}, nil, On(func(err *NotFoundError) error {
+			t.Error("The clause should not be called")
+
+			return nil
+		}))
+	}()
+
+	err, ok := reraised.(*TimeoutError)
+	if !ok || (err != timeout) {
+		t.Fatal("An error which is not caught should be raised again:", reraised)
+	}
+
+	if err.StackTrace()[0] != trace[0] {
+		t.Error("The error raised again should keep its stack trace")
+	}
+}
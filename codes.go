@@ -0,0 +1,151 @@
+package goerrors
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Severity is the severity level of an error code
+type Severity int
+
+// Severity levels
+const (
+	SeverityDebug Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+var (
+	// Names of severity levels
+	severityNames = [...]string{"debug", "info", "warning", "error", "critical"}
+
+	// Registry of error codes
+	codeRegistry = struct {
+		sync.RWMutex
+
+		byCode map[int64]*CodeDefinition
+		byName map[string]*CodeDefinition
+	}{
+		byCode: make(map[int64]*CodeDefinition),
+		byName: make(map[string]*CodeDefinition),
+	}
+)
+
+// String gets the name of the severity level
+func (s Severity) String() string {
+	if (s < 0) || (int(s) >= len(severityNames)) {
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+
+	return severityNames[s]
+}
+
+// CodeDefinition is the definition of a registered error code
+type CodeDefinition struct {
+	Code        int64    // Error code
+	Name        string   // Symbolic name (ex: `UserQuotaExceeded`)
+	Description string   // Description of the error
+	Message     string   // Default message, it may be a format
+	Severity    Severity // Severity level
+}
+
+// String formats the definition like `E1042 UserQuotaExceeded`
+func (cd *CodeDefinition) String() string {
+	return fmt.Sprintf("E%d %s", cd.Code, cd.Name)
+}
+
+// MakeError makes a standard error with this code and the default message formatted with the arguments `args`
+func (cd *CodeDefinition) MakeError(data interface{}, args ...interface{}) IStandardError {
+	res := &StandardError{code: cd.Code}
+	_ = res.Init(res, fmt.Sprintf(cd.Message, args...), data, nil, 1)
+
+	return res
+}
+
+// Raise raises a standard error with this code and the default message formatted with the arguments `args`
+func (cd *CodeDefinition) Raise(data interface{}, args ...interface{}) {
+	res := &StandardError{code: cd.Code}
+	_ = res.Init(res, fmt.Sprintf(cd.Message, args...), data, nil, 1)
+
+	res.raise(1)
+}
+
+// RegisterCode registers an error code, it should be called at the initialization of packages.
+// It raises an error if the code is zero (which means no code), or if the code or the name is already registered.
+func RegisterCode(code int64, name, description, message string, severity Severity) *CodeDefinition {
+	res := &CodeDefinition{
+		Code:        code,
+		Name:        name,
+		Description: description,
+		Message:     message,
+		Severity:    severity,
+	}
+
+	codeRegistry.Lock()
+	defer codeRegistry.Unlock()
+
+	if code == 0 {
+		MakeErrorWithDatas(0, res, "the error code 0 can't be registered (for %s)", name).raise(1)
+	}
+
+	if other, ok := codeRegistry.byCode[code]; ok {
+		MakeErrorWithDatas(code, res, "the error code %d is already registered as %s", code, other).raise(1)
+	}
+
+	if other, ok := codeRegistry.byName[name]; ok {
+		MakeErrorWithDatas(code, res, "the error name %s is already registered as %s", name, other).raise(1)
+	}
+
+	codeRegistry.byCode[code] = res
+	codeRegistry.byName[name] = res
+
+	return res
+}
+
+// Unregister an error code, it's used by tests to register a code several times
+func unregisterCode(code int64) {
+	codeRegistry.Lock()
+	defer codeRegistry.Unlock()
+
+	if def, ok := codeRegistry.byCode[code]; ok {
+		delete(codeRegistry.byCode, code)
+		delete(codeRegistry.byName, def.Name)
+	}
+}
+
+// LookupCode gets the definition of a registered error code, or nil if the code is not registered
+func LookupCode(code int64) *CodeDefinition {
+	codeRegistry.RLock()
+	defer codeRegistry.RUnlock()
+
+	return codeRegistry.byCode[code]
+}
+
+// LookupCodeName gets the definition of a registered error code from its name, or nil if the name is not registered
+func LookupCodeName(name string) *CodeDefinition {
+	codeRegistry.RLock()
+	defer codeRegistry.RUnlock()
+
+	return codeRegistry.byName[name]
+}
+
+// RegisteredCodes gets all definitions of registered error codes sorted by code
+func RegisteredCodes() []*CodeDefinition {
+	codeRegistry.RLock()
+
+	res := make([]*CodeDefinition, 0, len(codeRegistry.byCode))
+	for _, def := range codeRegistry.byCode {
+		res = append(res, def)
+	}
+
+	codeRegistry.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Code < res[j].Code
+	})
+
+	return res
+}
@@ -0,0 +1,99 @@
+package goerrors
+
+import (
+	"testing"
+)
+
+var (
+	testCode = RegisterCode(1042, "UserQuotaExceeded", "The user exceeds its quota", "quota of %s exceeded", SeverityWarning)
+)
+
+func TestSeverity(t *testing.T) {
+	if SeverityCritical.String() != "critical" {
+		t.Error("Bad severity name:", SeverityCritical)
+	}
+
+	if Severity(12).String() != "severity(12)" {
+		t.Error("Bad name of unknown severity:", Severity(12))
+	}
+}
+
+func TestRegisterCode(t *testing.T) {
+	if testCode.String() != "E1042 UserQuotaExceeded" {
+		t.Error("Bad code formatting:", testCode)
+	}
+
+	if (LookupCode(1042) != testCode) || (LookupCodeName("UserQuotaExceeded") != testCode) {
+		t.Error("The registered code should be found")
+	}
+
+	if (LookupCode(1043) != nil) || (LookupCodeName("Unknown") != nil) {
+		t.Error("An unregistered code should not be found")
+	}
+
+	def := RegisterCode(1044, "OtherCode", "", "", SeverityError)
+	t.Cleanup(func() { unregisterCode(1044) })
+
+	codes := RegisteredCodes()
+	if (len(codes) < 2) || (codes[len(codes)-2] != testCode) || (codes[len(codes)-1] != def) {
+		t.Error("The registered codes should be listed in order:", codes)
+	}
+}
+
+func TestRegisterBadCode(t *testing.T) {
+	check := func(title string, register func()) {
+		err := Try(func(err IError) error {
+			register()
+
+			return nil
+		}, func(err IError) error {
+			return err
+		}, nil)
+
+		if err == nil {
+			t.Error("Registering should fail with", title)
+		}
+	}
+
+	check("the code 0", func() { RegisterCode(0, "Zero", "", "", SeverityError) })
+	check("a duplicated code", func() { RegisterCode(1042, "Duplicate", "", "", SeverityError) })
+	check("a duplicated name", func() { RegisterCode(1045, "UserQuotaExceeded", "", "", SeverityError) })
+
+	if LookupCode(1045) != nil {
+		t.Error("A code which fails to be registered should not be found")
+	}
+
+	_ = RegisterCode(1046, "Registered", "", "", SeverityError)
+	unregisterCode(1046)
+
+	if (LookupCode(1046) != nil) || (LookupCodeName("Registered") != nil) {
+		t.Error("An unregistered code should not be found")
+	}
+}
+
+func TestCodeDefinitionErrors(t *testing.T) {
+	err := testCode.MakeError("data", "user-1")
+	if (err.GetCode() != 1042) || (err.GetMessage() != "quota of user-1 exceeded") || (err.GetData() != "data") {
+		t.Error("Bad error made from a code definition:", err)
+	}
+
+	if err.GetCodeDefinition() != testCode {
+		t.Error("The code definition should be found from the error")
+	}
+
+	if (MakeError("").GetCodeDefinition() != nil) || (MakeErrorWithDatas(1043, nil, "").GetCodeDefinition() != nil) {
+		t.Error("An error without registered code should not have a code definition")
+	}
+
+	rerr := Try(func(err IError) error {
+		testCode.Raise(nil, "user-2")
+
+		return nil
+	}, func(err IError) error {
+		return err
+	}, nil)
+
+	if serr, ok := rerr.(IStandardError); !ok || (serr.GetCodeDefinition() != testCode) {
+		t.Error("The raised error should have the code definition:", rerr)
+	}
+}
@@ -1,16 +1,51 @@
 package goerrors
 
+import (
+	"context"
+	"sync/atomic"
+)
+
 var (
-	errDebug bool = false
+	// Global debug flag, 0 for false and 1 for true, it's accessed atomically
+	errDebug int32 = 0
 )
 
+// Key of the debug mode in a context
+type tDebugKey struct{}
+
 // GetDebug returns the Debug boolean flag which indicates that the stack trace will be provided in errors
 func GetDebug() bool {
-	return errDebug
+	return atomic.LoadInt32(&errDebug) != 0
 }
 
 // SetDebug modifies the Debug boolean flag for enable or disable the stack trace in errors.
 // If the `debug` parameter is true, so the stack trace will be provided in errors.
+// This function can be safely called while other goroutines create errors.
 func SetDebug(debug bool) {
-	errDebug = debug
+	var value int32
+
+	if debug {
+		value = 1
+	}
+
+	atomic.StoreInt32(&errDebug, value)
+}
+
+// WithDebug returns a copy of the context `ctx` which carries its own debug mode.
+// The errors created with this context (with `MakeErrorContext`, `DecorateErrorContext` or `InitContext`) will have
+// a stack trace if `debug` is true, whatever the global Debug flag.
+func WithDebug(ctx context.Context, debug bool) context.Context {
+	return context.WithValue(ctx, tDebugKey{}, debug)
+}
+
+// GetContextDebug returns the debug mode carried by the context `ctx`,
+// or the global Debug flag if the context has no debug mode.
+func GetContextDebug(ctx context.Context) bool {
+	if ctx != nil {
+		if debug, ok := ctx.Value(tDebugKey{}).(bool); ok {
+			return debug
+		}
+	}
+
+	return GetDebug()
 }
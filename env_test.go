@@ -1,6 +1,10 @@
 package goerrors
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -25,3 +29,78 @@ func TestDebug(t *testing.T) {
 		t.Errorf("Error on false (second)")
 	}
 }
+
+func TestContextDebug(t *testing.T) {
+	SetDebug(false)
+
+	if GetContextDebug(context.Background()) {
+		t.Error("A context without debug mode should follow the global flag")
+	}
+
+	ctx := WithDebug(context.Background(), true)
+	if !GetContextDebug(ctx) {
+		t.Error("The context should carry the debug mode")
+	}
+
+	if len(MakeErrorContext(ctx, "error").StackTrace()) == 0 {
+		t.Error("An error made with a debug context should have a stack trace")
+	}
+
+	if trace := DecorateErrorContext(ctx, errors.New("error")).StackTrace(); len(trace) == 0 {
+		t.Error("An error decorated with a debug context should have a stack trace")
+	} else if trace[0].Function != "TestContextDebug" {
+		t.Error("The stack trace should start at the decoration:", trace[0])
+	}
+
+	if DecorateErrorContext(ctx, nil) != nil {
+		t.Error("The decoration of nil should be nil")
+	}
+
+	if serr := MakeError("error"); DecorateErrorContext(ctx, serr) != serr {
+		t.Error("A standard error should not be decorated")
+	}
+
+	if !strings.Contains(MakeErrorContext(ctx, "error").Error(), "TestContextDebug") {
+		t.Error("The stack trace captured with a debug context should be printed")
+	}
+
+	if len(MakeError("error").StackTrace()) != 0 {
+		t.Error("The debug mode of a context should not change the global flag")
+	}
+
+	SetDebug(true)
+	defer SetDebug(false)
+
+	if len(MakeErrorContext(WithDebug(context.Background(), false), "error").StackTrace()) != 0 {
+		t.Error("A context without debug should disable the stack trace")
+	}
+
+	gerr := &MyError{}
+	if len(gerr.InitContext(ctx, gerr, "", nil, nil, 0).StackTrace()) == 0 {
+		t.Error("An error initialized with a debug context should have a stack trace")
+	}
+}
+
+func TestConcurrentDebug(t *testing.T) {
+	defer SetDebug(false)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 16; i++ {
+		wg.Add(2)
+
+		go func(debug bool) {
+			defer wg.Done()
+
+			SetDebug(debug)
+		}(i%2 == 0)
+
+		go func() {
+			defer wg.Done()
+
+			_ = MakeError("error")
+		}()
+	}
+
+	wg.Wait()
+}
@@ -2,14 +2,23 @@ package goerrors
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"reflect"
+	"runtime"
+	"sync"
 	"unsafe"
 )
 
 var (
-	// Inheritance cache
-	errorHierarchies = make(map[string][]string)
+	// Inheritance cache, it maps an error type to its type hierarchy
+	errorHierarchies sync.Map
+
+	// Type of `GoError` structure, the root of all error type hierarchies
+	goErrorType = reflect.TypeOf(GoError{})
+
+	// Types of the mutexes, which are not copied with an error
+	mutexTypes = []reflect.Type{reflect.TypeOf(sync.Mutex{}), reflect.TypeOf(sync.RWMutex{})}
 )
 
 // IError Interface for extended Go errors
@@ -29,6 +38,22 @@ type IError interface {
 	// Get custon data
 	GetData() interface{}
 
+	// Get the stack trace, it's empty if the error was not created in debug mode
+	StackTrace() []Frame
+
+	// Get the sites where the error was raised or raised again, it's empty if the error has no stack trace
+	RaiseHistory() []Frame
+
+	// Get the stack trace of the code which launched the goroutine where the error ended (see `Go`), it's empty if
+	// the goroutine was not launched in debug mode
+	LauncherStack() []Frame
+
+	// Get the value of a field
+	GetField(key string) (interface{}, bool)
+
+	// Get all fields
+	Fields() map[string]interface{}
+
 	// Complete try/catch/finally block
 	Try(try, catch, finally ErrorHandler) error
 
@@ -44,6 +69,9 @@ type IError interface {
 	// Get the real reference on this error
 	getReference() IError
 
+	// Get the `GoError` structure embedded in this error
+	getGoError() *GoError
+
 	// This method construct the stack trace only in 'Debug' Mode
 	populateStackTrace(pruneLevels uint)
 
@@ -52,6 +80,27 @@ type IError interface {
 
 	// Raise error with pruned levels
 	raise(pruneLevels uint)
+
+	// Lock the mutable state while the error is copied, it returns the unlock function
+	lockState() func()
+
+	// Clone the mutable state shared with the original error after a copy
+	cloneState()
+}
+
+// ICloneableError Interface for a customized error which has a mutable state, like a map, a slice or a mutex.
+// A raised error is a copy of the error, so that a sentinel error is not modified by the raise. The copy is shallow,
+// so a customized error which doesn't implement this interface must not modify its fields after the initialization.
+type ICloneableError interface {
+	// Base interface
+	IError
+
+	// Lock the mutable state of the original error while it's copied, it returns the unlock function
+	LockState() func()
+
+	// Clone the mutable state shared with the original error, it's called on the copy while the original error is
+	// locked. The mutexes of the copy (`sync.Mutex` and `sync.RWMutex` fields) are not copied, they're unlocked.
+	CloneState()
 }
 
 // ErrorHandler Handler for executing Try, Catch, or Finally block
@@ -59,11 +108,30 @@ type ErrorHandler func(err IError) error
 
 // GoError Basic error structure
 type GoError struct {
-	source  error        // Cause or original error
-	message string       // Error message
-	trace   []string     // Stack trace
-	data    interface{}  // Custom data
-	errType reflect.Type // Type of this error
+	source  error                  // Cause or original error
+	message string                 // Error message
+	trace   *tStack                // Stack trace
+	raises  []Frame                // Sites where the error was raised
+	launch  []Frame                // Stack trace of the code which launched the goroutine where the error ended
+	data    interface{}            // Custom data
+	fields  map[string]interface{} // Key/value fields
+	errType reflect.Type           // Type of this error
+	self    IError                 // Customized error which embeds this structure
+	origin  *GoError               // Original error when this error is a raised copy
+}
+
+// InitError is the error returned by `Init` when it's misused: the value passed is not a pointer on a structure which
+// embeds the initialized `GoError`, or the error is already initialized
+type InitError struct {
+	StandardError
+}
+
+// Make an initialization error
+func makeInitError(message string, args ...interface{}) IStandardError {
+	res := &InitError{}
+	_ = res.init(GetDebug(), res, fmt.Sprintf(message, args...), nil, nil, 1)
+
+	return res
 }
 
 // Standard method of `error` interface
@@ -90,11 +158,11 @@ func (goErr *GoError) Error() string {
 
 		if source != nil {
 			_, _ = fmt.Fprintln(&out)
-			_, _ = fmt.Fprintln(&out, "Source:", source)
+			_, _ = fmt.Fprintln(&out, "Source:", source.Error())
 		}
 	} else {
 		if source != nil {
-			_, _ = fmt.Fprintln(&out, source)
+			_, _ = fmt.Fprintln(&out, source.Error())
 		}
 
 		if data != nil {
@@ -102,27 +170,26 @@ func (goErr *GoError) Error() string {
 		}
 	}
 
-	// Prints stack trace only in debug mode
-	if errDebug {
-		for _, entry := range goErr.trace {
-			_, _ = fmt.Fprintln(&out, "   ", entry)
-		}
-
-		// Prints a separator if stack trace is not empty
-		if len(goErr.trace) > 0 {
-			const sep = "------------------------------------------------------------------------------"
+	// Prints fields
+	writeFields(&out, err.Fields())
 
-			_, _ = fmt.Fprintln(&out, sep)
-		}
+	// Prints added informations
+	if serr, ok := err.(IStandardError); ok {
+		writeInfos(&out, serr.Infos())
 	}
 
+	// Prints stack trace, it's only captured in debug mode
+	writeTrace(&out, goErr.trace.Frames(), goErr.raises, goErr.launch)
+
 	// Return content of the buffer resulting from printing theses informations
 	return out.String()
 }
 
 // GetName gets error name
 func (goErr *GoError) GetName() string {
-	return goErr.errType.PkgPath() + "." + goErr.errType.Name()
+	errType := goErr.getType()
+
+	return errType.PkgPath() + "." + errType.Name()
 }
 
 // GetSource gets cause error (parent error)
@@ -140,6 +207,22 @@ func (goErr *GoError) GetData() interface{} {
 	return goErr.data
 }
 
+// StackTrace gets the stack trace, it's empty if the error was not created in debug mode
+func (goErr *GoError) StackTrace() []Frame {
+	return goErr.trace.Frames()
+}
+
+// RaiseHistory gets the sites where the error was raised or raised again, it's empty if the error has no stack trace
+func (goErr *GoError) RaiseHistory() []Frame {
+	return append([]Frame(nil), goErr.raises...)
+}
+
+// LauncherStack gets the stack trace of the code which launched the goroutine where the error ended (see `Go`),
+// it's empty if the goroutine was not launched in debug mode
+func (goErr *GoError) LauncherStack() []Frame {
+	return append([]Frame(nil), goErr.launch...)
+}
+
 // Try completes try/catch/finally block
 func (goErr *GoError) Try(try, catch, finally ErrorHandler) (err error) {
 	defer goErr.Catch(&err, catch, finally)
@@ -179,25 +262,52 @@ func (goErr *GoError) Catch(err *error, catch, finally ErrorHandler) {
 	}
 
 	if catch != nil {
-		resErr = catch(resErr.(IError))
+		ierr := resErr.(IError)
+		catchHooks.fire(ierr)
+
+		resErr = catch(ierr)
 	}
 }
 
-// Raise for raising error (obviously)
+// Raise for raising error (obviously).
+// The raised error is a shallow copy of this error, see `ICloneableError` for the customized errors with a mutable
+// state.
 func (goErr *GoError) Raise() {
 	goErr.raise(1)
 }
 
 // IsParentOf tests if this error is one of parents of error `err` passed in parameter
 func (goErr *GoError) IsParentOf(err error) bool {
-	gerr, ok := err.(IError)
+	return isParentOf(goErr.GetName(), err)
+}
+
+// Unwrap returns the cause error, it's used by the standard functions `errors.Unwrap`, `errors.Is` and `errors.As`
+func (goErr *GoError) Unwrap() error {
+	return goErr.source
+}
+
+// Is tests if this error is the error `target`, it's used by the standard function `errors.Is`.
+// If the target is an uninitialized error value like `&ErrorBase{}`, it's used as a type marker: this error matches if
+// the type of the target is one of its parents. Otherwise, the target is compared by identity, like a sentinel error,
+// and a raised copy of an error (see `Raise`) is the original error.
+func (goErr *GoError) Is(target error) bool {
+	ierr, ok := target.(IError)
 	if !ok {
 		return false
 	}
 
-	name := goErr.GetName()
+	if targetErr := ierr.getGoError(); targetErr.errType != nil {
+		return (goErr == targetErr) || ((goErr.origin != nil) && (goErr.origin == targetErr))
+	}
 
-	for _, parent := range gerr.getParents() {
+	targetType := reflect.TypeOf(target)
+	if targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+
+	name := targetType.PkgPath() + "." + targetType.Name()
+
+	for _, parent := range goErr.getReference().getParents() {
 		if parent == name {
 			return true
 		}
@@ -206,29 +316,219 @@ func (goErr *GoError) IsParentOf(err error) bool {
 	return false
 }
 
-// Init for initializing customized error
+// As finds in this error the value which can be assigned to `target`, it's used by the standard function `errors.As`.
+// If `target` is a pointer on a pointer to one of the parent error types, it will point on the embedded parent error.
+func (goErr *GoError) As(target interface{}) bool {
+	targetValue := reflect.ValueOf(target)
+	if (targetValue.Kind() != reflect.Ptr) || targetValue.IsNil() {
+		return false
+	}
+
+	targetType := targetValue.Type().Elem()
+	ref := reflect.ValueOf(goErr.getReference())
+
+	if ref.Type().AssignableTo(targetType) {
+		targetValue.Elem().Set(ref)
+
+		return true
+	}
+
+	if targetType.Kind() != reflect.Ptr {
+		return false
+	}
+
+	parent := _findEmbedded(ref.Elem(), targetType.Elem())
+	if !parent.IsValid() || !parent.CanInterface() {
+		return false
+	}
+
+	targetValue.Elem().Set(parent.Addr())
+
+	return true
+}
+
+// Init for initializing customized error.
+// The fields of the customized error must not be modified after, unless it implements `ICloneableError`.
 func (goErr *GoError) Init(value interface{}, message string, data interface{}, source error, pruneLevels uint) IError {
-	if goErr.errType == nil {
-		goErr.setType(value)
+	res := goErr.init(GetDebug(), value, message, data, source, pruneLevels+1)
+	createHooks.fire(res)
+
+	return res
+}
+
+// InitContext is like `Init` but the stack trace is captured according to the debug mode of the context `ctx`
+func (goErr *GoError) InitContext(
+	ctx context.Context,
+	value interface{},
+	message string,
+	data interface{},
+	source error,
+	pruneLevels uint,
+) IError {
+	res := goErr.init(GetContextDebug(ctx), value, message, data, source, pruneLevels+1)
+	createHooks.fire(res)
+
+	return res
+}
 
-		goErr.message = message
-		goErr.data = data
-		goErr.source = source
+// Initialize customized error, the stack trace is captured only if `debug` is true.
+// The value `value` must be a pointer on the customized error which embeds this `GoError`,
+// else an `InitError` is returned and this error stays uninitialized.
+func (goErr *GoError) init(
+	debug bool,
+	value interface{},
+	message string,
+	data interface{},
+	source error,
+	pruneLevels uint,
+) IError {
+	if goErr.errType != nil {
+		return makeInitError("The error %s is already initialized", goErr.errType)
+	}
 
-		goErr.populateStackTrace(pruneLevels + 1)
+	ref, err := goErr.checkReference(value)
+	if err != nil {
+		return err
 	}
 
-	return goErr
+	goErr.setType(value)
+
+	goErr.self = ref
+	goErr.message = message
+	goErr.data = data
+	goErr.source = source
+
+	if debug {
+		goErr.trace = captureStack(pruneLevels + 1)
+	}
+
+	return ref
 }
 
-// Raise the error
+// Check that the value passed to `Init` is a pointer on the customized error which embeds this `GoError`
+func (goErr *GoError) checkReference(value interface{}) (IError, IError) {
+	v := reflect.ValueOf(value)
+	if (v.Kind() != reflect.Ptr) || (v.Type().Elem().Kind() != reflect.Struct) {
+		return nil, makeInitError("The value passed to Init should be a pointer on a structure, not %T", value)
+	}
+
+	if v.IsNil() {
+		return nil, makeInitError("The value passed to Init should not be a nil pointer")
+	}
+
+	ref, ok := value.(IError)
+	if !ok {
+		return nil, makeInitError("The type %T passed to Init does not embed GoError", value)
+	}
+
+	if ref.getGoError() != goErr {
+		return nil, makeInitError("The value passed to Init does not embed the initialized error")
+	}
+
+	return ref, nil
+}
+
+// Raise a copy of the error, so this error is never modified and can be shared (like package-level error values).
+// The stack trace of the error creation is kept and the raise site is added to the raise history of the copy.
 func (goErr *GoError) raise(pruneLevels uint) {
-	res := goErr.getReference()
+	res, inner := goErr.copyError()
 	res.populateStackTrace(pruneLevels + 1)
 
+	if inner.trace != nil {
+		var pcs [1]uintptr
+
+		if runtime.Callers(int(pruneLevels+2), pcs[:]) > 0 {
+			inner.raises = append(inner.raises, resolveFrames(pcs[0])...)
+		}
+	}
+
+	raiseHooks.fire(res)
+
 	panic(res)
 }
 
+// Make a copy of the real reference on this error, it returns the copy and the `GoError` structure in the copy.
+// If the error can't be copied, it returns the error itself.
+func (goErr *GoError) copyError() (IError, *GoError) {
+	ref := goErr.getReference()
+
+	value := reflect.ValueOf(ref)
+	if (value.Kind() != reflect.Ptr) || (value.Elem().Kind() != reflect.Struct) {
+		return ref, goErr
+	}
+
+	res := reflect.New(value.Type().Elem())
+
+	copied := res.Interface().(IError)
+
+	// The state shared by the copy is cloned while the original error is locked
+	func() {
+		defer ref.lockState()()
+
+		if cloneable, ok := ref.(ICloneableError); ok {
+			defer cloneable.LockState()()
+
+			copyFields(res.Elem(), value.Elem())
+			copied.cloneState()
+			copied.(ICloneableError).CloneState()
+
+			return
+		}
+
+		copyFields(res.Elem(), value.Elem())
+		copied.cloneState()
+	}()
+
+	inner := copied.getGoError()
+	if inner.self != nil {
+		inner.self = copied
+	}
+
+	// The copy of a copy keeps the first original error
+	if inner.origin == nil {
+		inner.origin = goErr
+	}
+
+	return copied, inner
+}
+
+// Copy the fields of the structure `src` in the structure `dst`, the mutexes are not copied so they're unlocked in the
+// copy, and they're not read while they may be held
+func copyFields(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+
+		isMutex := false
+		for _, mutexType := range mutexTypes {
+			isMutex = isMutex || (field.Type() == mutexType)
+		}
+
+		switch {
+		case isMutex:
+		case field.Kind() == reflect.Struct:
+			copyFields(dst.Field(i), field)
+		default:
+			// The unexported fields can't be set with reflection, so they're accessed by their address
+			target := dst.Field(i)
+
+			reflect.NewAt(target.Type(), unsafe.Pointer(target.UnsafeAddr())).Elem().Set(
+				reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem(),
+			)
+		}
+	}
+}
+
+// Lock the mutable state while the error is copied, there is no lock
+func (goErr *GoError) lockState() func() {
+	return func() {}
+}
+
+// Clone the mutable state shared with the original error after a copy
+func (goErr *GoError) cloneState() {
+	goErr.fields = goErr.Fields()
+	goErr.raises = goErr.RaiseHistory()
+}
+
 // Define the type of customized error
 func (goErr *GoError) setType(value interface{}) {
 	errType := reflect.ValueOf(value).Type()
@@ -239,39 +539,78 @@ func (goErr *GoError) setType(value interface{}) {
 	goErr.errType = errType
 }
 
-// Get the real reference on this error
-func (goErr *GoError) getReference() IError {
+// Get the type of customized error, an uninitialized error has the type `GoError` but it stays uninitialized
+func (goErr *GoError) getType() reflect.Type {
 	if goErr.errType == nil {
-		goErr.setType(goErr)
+		return goErrorType
 	}
 
-	ptr := unsafe.Pointer(reflect.ValueOf(goErr).Pointer())
+	return goErr.errType
+}
 
-	return reflect.NewAt(goErr.errType, ptr).Interface().(IError)
+// Get the real reference on this error, it doesn't change the error
+func (goErr *GoError) getReference() IError {
+	if goErr.self != nil {
+		return goErr.self
+	}
+
+	return goErr
+}
+
+// Get the `GoError` structure embedded in this error
+func (goErr *GoError) getGoError() *GoError {
+	return goErr
 }
 
-// This method construct the stack trace only in 'Debug' Mode
+// This method construct the stack trace only in 'Debug' Mode, and only if the error has no stack trace yet
 func (goErr *GoError) populateStackTrace(pruneLevels uint) {
-	// If we aren't in debugging mode,
-	if !errDebug {
+	// If we aren't in debugging mode, or if the stack trace is already captured,
+	if !GetDebug() || (goErr.trace != nil) {
 		// Do nothing
 		return
 	}
 
-	goErr.trace = getTrace(pruneLevels + 1)
+	goErr.trace = captureStack(pruneLevels + 1)
 }
 
 // Get type of this error
 func (goErr *GoError) getParents() []string {
-	name := goErr.GetName()
-	res, ok := errorHierarchies[name]
+	errType := goErr.getType()
 
+	if res, ok := errorHierarchies.Load(errType); ok {
+		return res.([]string)
+	}
+
+	res, _ := errorHierarchies.LoadOrStore(errType, _getTypeHierarchy(errType, goErrorType))
+
+	return res.([]string)
+}
+
+// Test if the error type named `name` is one of parents of error `err`
+func isParentOf(name string, err error) bool {
+	gerr, ok := err.(IError)
 	if !ok {
-		res = _getTypeHierarchy(goErr.errType, reflect.TypeOf(goErr).Elem())
-		errorHierarchies[name] = res
+		return false
 	}
 
-	return res
+	for _, parent := range gerr.getParents() {
+		if parent == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetHierarchy gets the names of error types in the hierarchy of the error `err`, from the type of the error to the
+// `GoError` type. It returns nil if the error is not an IError.
+func GetHierarchy(err error) []string {
+	ierr, ok := err.(IError)
+	if !ok {
+		return nil
+	}
+
+	return append([]string(nil), ierr.getParents()...)
 }
 
 // GetSource gets the error source from an error, or returns nil if the error passed in argument is not an IError
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"os"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -37,8 +40,10 @@ func TestInitError(t *testing.T) {
 	src := fmt.Errorf("")
 	data := new(int)
 
-	gerr := MyError{}
-	_ = gerr.Init(gerr, "--message--", data, src, 0)
+	gerr := &MyError{}
+	if err := gerr.Init(gerr, "--message--", data, src, 0); err != IError(gerr) {
+		t.Error("The initialization should return the customized error:", err)
+	}
 
 	if gerr.errType != _errType {
 		t.Error("Bad type")
@@ -57,6 +62,65 @@ func TestInitError(t *testing.T) {
 	}
 }
 
+func TestInitErrorMisuse(t *testing.T) {
+	other := &MyError{}
+	notEmbedding := &struct{ Field int }{}
+
+	for name, value := range map[string]interface{}{
+		"value":         MyError{},
+		"nil":           (*MyError)(nil),
+		"not embedding": notEmbedding,
+		"other error":   other,
+		"not a struct":  new(int),
+	} {
+		gerr := &MyError{}
+
+		err := gerr.Init(value, "message", nil, nil, 0)
+		if _, ok := err.(*InitError); !ok {
+			t.Error("The misuse should return an initialization error for", name, ":", err)
+		}
+
+		if gerr.errType != nil {
+			t.Error("The error should stay uninitialized for", name)
+		}
+	}
+
+	gerr := &MyError{}
+	_ = gerr.Init(gerr, "message", nil, nil, 0)
+
+	if err, ok := gerr.Init(gerr, "other", nil, nil, 0).(*InitError); !ok || !strings.Contains(err.GetMessage(), "already") {
+		t.Error("A double initialization should return an initialization error:", err)
+	}
+
+	if gerr.GetMessage() != "message" {
+		t.Error("A double initialization should not modify the error")
+	}
+}
+
+func TestUninitializedErrorUse(t *testing.T) {
+	marker := &MyError{}
+
+	// The use of an uninitialized error doesn't initialize it
+	_ = marker.Error()
+	_ = fmt.Sprintf("%v %+v", marker, marker)
+	_ = marker.GetName()
+
+	if marker.errType != nil {
+		t.Fatal("The use of an uninitialized error should not initialize it")
+	}
+
+	err := &MyError{}
+	_ = err.Init(err, "error", nil, nil, 0)
+
+	if !errors.Is(err, marker) {
+		t.Error("An uninitialized error used before should still be a type marker")
+	}
+
+	if res := marker.Init(marker, "message", nil, nil, 0); res != IError(marker) {
+		t.Error("An uninitialized error used before should be initialized:", res)
+	}
+}
+
 func TestShowErrorNoDebug(t *testing.T) {
 	SetDebug(false)
 
@@ -66,16 +130,16 @@ func TestShowErrorNoDebug(t *testing.T) {
 	r := rand.Int63()
 	id := uuid.New()
 
-	err := MakeError("%s:%d", id, r).(*tStandardError)
+	err := MakeError("%s:%d", id, r).(*StandardError)
 	if err.message != fmt.Sprintf("%s:%d", id, r) {
 		t.Error("Failed on MakeError:", err.message, "!=", fmt.Sprintf("%s:%d", id, r))
 	}
 
-	if len(err.trace) != 0 {
-		t.Error("Failed on empty trace: ", err.trace)
+	if err.trace != nil {
+		t.Error("Failed on empty trace: ", err.StackTrace())
 	}
 
-	if err.infos.Len() != 0 {
+	if len(err.Infos()) != 0 {
 		t.Error("Failed on no-infos")
 	}
 }
@@ -83,8 +147,8 @@ func TestShowErrorNoDebug(t *testing.T) {
 func TestShowErrorDebug(t *testing.T) {
 	SetDebug(true)
 
-	err := MakeError("").(*tStandardError)
-	if len(err.trace) == 0 {
+	err := MakeError("").(*StandardError)
+	if len(err.StackTrace()) == 0 {
 		t.Error("Failed on stack trace")
 	}
 }
@@ -172,3 +236,396 @@ func TestErrNakedRaise(t *testing.T) {
 
 	(&MyError{}).raise(0)
 }
+
+type MyChildError struct {
+	MyError
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	src := errors.New("error")
+
+	if errors.Unwrap(DecorateError(src)) != src {
+		t.Error("Unwrap should return the source error")
+	}
+
+	if errors.Unwrap(MakeError("error")) != nil {
+		t.Error("Unwrap should return nil without source error")
+	}
+
+	_, openErr := os.Open(".a_file_5123351069599224559.txt")
+
+	if !errors.Is(DecorateError(openErr), os.ErrNotExist) {
+		t.Error("A decorated error should match its source")
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	gerr := &MyChildError{}
+	_ = gerr.Init(gerr, "--message--", nil, nil, 0)
+
+	if !errors.Is(gerr, &MyError{}) {
+		t.Error("An error should match its parent")
+	}
+
+	if !errors.Is(gerr, &MyChildError{}) {
+		t.Error("An error should match its own type")
+	}
+
+	if !errors.Is(DecorateError(gerr), &MyError{}) {
+		t.Error("A decorated error should match the parent of its source")
+	}
+
+	sentinel := MakeError("not found")
+	if !errors.Is(DecorateError(sentinel), sentinel) || errors.Is(MakeError("permission denied"), sentinel) {
+		t.Error("An initialized error should be compared by identity")
+	}
+
+	if errors.Is(MakeError("error"), &MyError{}) {
+		t.Error("An error should not match an error out of its hierarchy")
+	}
+
+	if gerr.Is(errors.New("error")) {
+		t.Error("An error should not match a basic error")
+	}
+}
+
+func TestErrorAs(t *testing.T) {
+	gerr := &MyChildError{}
+	_ = gerr.Init(gerr, "--message--", nil, nil, 0)
+
+	var child *MyChildError
+
+	if !errors.As(DecorateError(gerr), &child) || (child != gerr) {
+		t.Error("As should find the error itself")
+	}
+
+	var parent *MyError
+
+	if !errors.As(gerr, &parent) || (parent != &gerr.MyError) {
+		t.Error("As should find the embedded parent error")
+	}
+
+	var other *struct{ GoError }
+
+	if errors.As(gerr, &other) {
+		t.Error("As should not find an error out of the hierarchy")
+	}
+
+	if gerr.As(nil) || gerr.As(other) {
+		t.Error("As should fail with a bad target")
+	}
+}
+
+type MyConcurrentError struct {
+	MyError
+}
+
+func TestConcurrentHierarchy(t *testing.T) {
+	const count = 32
+
+	parent := &MyError{}
+	_ = parent.Init(parent, "parent", nil, nil, 0)
+
+	var wg sync.WaitGroup
+
+	results := make(chan bool, 2*count)
+
+	for i := 0; i < count; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			gerr := &MyConcurrentError{}
+			_ = gerr.Init(gerr, "error", nil, nil, 0)
+
+			results <- parent.IsParentOf(gerr)
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			err := parent.Try(func(err IError) error {
+				gerr := &MyConcurrentError{}
+				gerr.Init(gerr, "error", nil, nil, 0).Raise()
+
+				return nil
+			}, nil, nil)
+
+			results <- parent.IsParentOf(err)
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+
+	for res := range results {
+		if !res {
+			t.Error("The hierarchy should be found by concurrent calls")
+		}
+	}
+
+	if hierarchy, ok := errorHierarchies.Load(reflect.TypeOf(MyConcurrentError{})); !ok || (len(hierarchy.([]string)) != 3) {
+		t.Error("The hierarchy should be cached by error type:", hierarchy)
+	}
+}
+
+func TestGetHierarchy(t *testing.T) {
+	if GetHierarchy(errors.New("error")) != nil {
+		t.Error("A basic error should not have a hierarchy")
+	}
+
+	gerr := &MyChildError{}
+	_ = gerr.Init(gerr, "", nil, nil, 0)
+
+	hierarchy := GetHierarchy(gerr)
+	expected := []string{
+		"github.com/corebreaker/goerrors.MyChildError",
+		"github.com/corebreaker/goerrors.MyError",
+		"github.com/corebreaker/goerrors.GoError",
+	}
+
+	if !reflect.DeepEqual(hierarchy, expected) {
+		t.Error("Bad hierarchy:", hierarchy)
+	}
+
+	hierarchy[0] = ""
+	if GetHierarchy(gerr)[0] == "" {
+		t.Error("The hierarchy should be a copy")
+	}
+}
+
+func TestRaiseHistory(t *testing.T) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	created := MakeError("error")
+	trace := created.StackTrace()
+
+	err := Try(func(IError) error {
+		_ = Try(func(IError) error {
+			created.Raise()
+
+			return nil
+		}, func(err IError) error {
+			RaiseError(err)
+
+			return nil
+		}, nil)
+
+		return nil
+	}, func(err IError) error {
+		return err
+	}, nil)
+
+	ierr, ok := err.(IError)
+	if !ok {
+		t.Fatal("The error should be caught:", err)
+	}
+
+	if ierr.StackTrace()[0] != trace[0] {
+		t.Error("The stack trace of the error creation should be kept:", ierr.StackTrace()[0])
+	}
+
+	raises := ierr.RaiseHistory()
+	if (len(raises) != 2) ||
+		(raises[0].Function != "TestRaiseHistory.func1.1") ||
+		(raises[1].Function != "TestRaiseHistory.func1.2") {
+		t.Fatal("Bad raise history:", raises)
+	}
+
+	if !strings.Contains(ierr.Error(), "Raised at:\n    "+raises[0].String()+"\n    "+raises[1].String()+"\n---") {
+		t.Error("The raise history should be printed:", ierr.Error())
+	}
+}
+
+func TestRaiseWithoutTrace(t *testing.T) {
+	created := MakeError("error")
+
+	SetDebug(true)
+	defer SetDebug(false)
+
+	err := Try(func(IError) error {
+		created.Raise()
+
+		return nil
+	}, func(err IError) error {
+		return err
+	}, nil)
+
+	ierr := err.(IError)
+	if (len(ierr.StackTrace()) == 0) || (ierr.StackTrace()[0].Function != "TestRaiseWithoutTrace.func1") {
+		t.Error("The stack trace of an error without stack trace should be captured when raised")
+	}
+
+	SetDebug(false)
+
+	if len(MakeError("").RaiseHistory()) != 0 {
+		t.Error("An error which is not raised should not have a raise history")
+	}
+}
+
+var errSentinel = MakeError("sentinel").WithField("key", "value")
+
+func TestRaiseCopy(t *testing.T) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	err := Try(func(IError) error {
+		errSentinel.Raise()
+
+		return nil
+	}, func(err IError) error {
+		return err
+	}, nil)
+
+	copied, ok := err.(*StandardError)
+	if !ok || (copied == errSentinel) {
+		t.Fatal("A copy of the error should be raised:", err)
+	}
+
+	if !errSentinel.IsParentOf(copied) || !errors.Is(copied, errSentinel) {
+		t.Error("The copy should be in the hierarchy of the original error")
+	}
+
+	var recopied error
+
+	func() {
+		defer Catch(&recopied, nil, nil)
+
+		RaiseError(copied)
+	}()
+
+	if (recopied == error(copied)) || !errors.Is(recopied, errSentinel) {
+		t.Error("A copy raised again should still be the original error:", recopied)
+	}
+
+	if errors.Is(copied, MakeError("sentinel")) || errors.Is(errSentinel, copied) {
+		t.Error("A copy should not be another error of the same type")
+	}
+
+	if (len(errSentinel.RaiseHistory()) != 0) || (errSentinel.StackTrace() != nil) {
+		t.Error("The original error should not be modified")
+	}
+
+	if (len(copied.RaiseHistory()) != 1) || (len(copied.StackTrace()) == 0) {
+		t.Error("The copy should have its own stack trace and raise history")
+	}
+
+	_ = copied.WithField("key", "other").AddInfo("info")
+
+	if value, _ := errSentinel.GetField("key"); (value != "value") || (len(errSentinel.Infos()) != 0) {
+		t.Error("The modification of the copy should not modify the original error")
+	}
+}
+
+// Customized error with a mutable state
+type tCountersError struct {
+	GoError
+
+	mutex    sync.Mutex
+	counters map[string]int
+}
+
+func (e *tCountersError) Increment(name string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.counters[name]++
+}
+
+func (e *tCountersError) Count(name string) int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.counters[name]
+}
+
+func (e *tCountersError) LockState() func() {
+	e.mutex.Lock()
+
+	return e.mutex.Unlock
+}
+
+func (e *tCountersError) CloneState() {
+	counters := make(map[string]int, len(e.counters))
+	for name, count := range e.counters {
+		counters[name] = count
+	}
+
+	e.counters = counters
+}
+
+func TestRaiseCopyCloneable(t *testing.T) {
+	sentinel := &tCountersError{counters: map[string]int{"init": 1}}
+	_ = sentinel.Init(sentinel, "counters", nil, nil, 0)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 100; i++ {
+			sentinel.Increment("concurrent")
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		err := Try(func(IError) error {
+			sentinel.Raise()
+
+			return nil
+		}, func(err IError) error {
+			return err
+		}, nil)
+
+		copied, ok := err.(*tCountersError)
+		if !ok || (copied == sentinel) || (copied.Count("init") != 1) {
+			t.Fatal("A copy of the error should be raised:", err)
+		}
+
+		copied.Increment("copy")
+	}
+
+	<-done
+
+	if (sentinel.Count("copy") != 0) || (sentinel.Count("concurrent") != 100) {
+		t.Error("The state of the copies should not be shared with the original error:", sentinel.counters)
+	}
+}
+
+func TestConcurrentRaise(t *testing.T) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	var wg sync.WaitGroup
+
+	errs := make(chan IError, 16)
+
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_ = Try(func(IError) error {
+				errSentinel.Raise()
+
+				return nil
+			}, func(err IError) error {
+				errs <- err
+
+				return nil
+			}, nil)
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if (err == errSentinel) || (len(err.RaiseHistory()) != 1) {
+			t.Error("Each raise should have its own copy:", err.RaiseHistory())
+		}
+	}
+}
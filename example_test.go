@@ -63,5 +63,5 @@ func Example() {
 	}
 
 	// Output:
-	// StandardError: open .a_file_5123351069599224559.txt: no such file or directory
+	// github.com/corebreaker/goerrors.StandardError: open .a_file_5123351069599224559.txt: no such file or directory
 }
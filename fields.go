@@ -0,0 +1,101 @@
+package goerrors
+
+import (
+	"errors"
+	"sort"
+)
+
+// WithField adds the field `key` with the value `value` on this error
+func (goErr *GoError) WithField(key string, value interface{}) IError {
+	goErr.setField(key, value)
+
+	return goErr.getReference()
+}
+
+// WithFields adds the fields `fields` on this error
+func (goErr *GoError) WithFields(fields map[string]interface{}) IError {
+	for key, value := range fields {
+		goErr.setField(key, value)
+	}
+
+	return goErr.getReference()
+}
+
+// GetField gets the value of the field `key`, the boolean result is false if the field doesn't exist
+func (goErr *GoError) GetField(key string) (interface{}, bool) {
+	value, ok := goErr.fields[key]
+
+	return value, ok
+}
+
+// Fields gets a copy of the fields of this error
+func (goErr *GoError) Fields() map[string]interface{} {
+	if len(goErr.fields) == 0 {
+		return nil
+	}
+
+	res := make(map[string]interface{}, len(goErr.fields))
+	for key, value := range goErr.fields {
+		res[key] = value
+	}
+
+	return res
+}
+
+// Set a field
+func (goErr *GoError) setField(key string, value interface{}) {
+	if goErr.fields == nil {
+		goErr.fields = make(map[string]interface{})
+	}
+
+	goErr.fields[key] = value
+}
+
+// WithField adds the field `key` with the value `value` on standard error
+func (se *StandardError) WithField(key string, value interface{}) IStandardError {
+	se.setField(key, value)
+
+	return se.getStandardReference()
+}
+
+// WithFields adds the fields `fields` on standard error
+func (se *StandardError) WithFields(fields map[string]interface{}) IStandardError {
+	for key, value := range fields {
+		se.setField(key, value)
+	}
+
+	return se.getStandardReference()
+}
+
+// GetFieldAs gets the value of the field `key` of the error `err` or of one of its causes, with the type `T`.
+// The boolean result is false if there is no such field, or if the value has not the type `T`.
+func GetFieldAs[T any](err error, key string) (T, bool) {
+	for cause := err; cause != nil; cause = errors.Unwrap(cause) {
+		ierr, ok := cause.(IError)
+		if !ok {
+			continue
+		}
+
+		if value, found := ierr.GetField(key); found {
+			res, ok := value.(T)
+
+			return res, ok
+		}
+	}
+
+	var res T
+
+	return res, false
+}
+
+// Get the keys of fields in order
+func sortedKeys(fields map[string]interface{}) []string {
+	res := make([]string, 0, len(fields))
+	for key := range fields {
+		res = append(res, key)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -0,0 +1,105 @@
+package goerrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	gerr := &MyError{}
+	_ = gerr.Init(gerr, "message", nil, nil, 0)
+
+	if gerr.Fields() != nil {
+		t.Error("A new error should not have fields")
+	}
+
+	if gerr.WithField("user_id", 12) != IError(gerr) {
+		t.Error("WithField should return the error itself")
+	}
+
+	if gerr.WithFields(map[string]interface{}{"tenant": "acme", "shard": 3}) != IError(gerr) {
+		t.Error("WithFields should return the error itself")
+	}
+
+	if value, ok := gerr.GetField("user_id"); !ok || (value != 12) {
+		t.Error("Bad field value:", value)
+	}
+
+	if _, ok := gerr.GetField("unknown"); ok {
+		t.Error("An unknown field should not be found")
+	}
+
+	fields := gerr.Fields()
+	if !reflect.DeepEqual(fields, map[string]interface{}{"user_id": 12, "tenant": "acme", "shard": 3}) {
+		t.Error("Bad fields:", fields)
+	}
+
+	fields["user_id"] = 0
+	if value, _ := gerr.GetField("user_id"); value != 12 {
+		t.Error("The fields should be a copy")
+	}
+}
+
+func TestStandardErrorFields(t *testing.T) {
+	err := MakeError("message").WithField("user_id", 12).WithFields(map[string]interface{}{"tenant": "acme"})
+
+	if !strings.Contains(err.Error(), "Fields:\n  tenant: acme\n  user_id: 12\n") {
+		t.Error("The fields should be printed in order:", err.Error())
+	}
+
+	if res := fmt.Sprintf("%+v", err); !strings.Contains(res, "Fields:\n  tenant: acme\n") {
+		t.Error("The fields should be in the report:", res)
+	}
+
+	data, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+
+	decoded, jerr := UnmarshalError(data)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+
+	if !reflect.DeepEqual(decoded.Fields(), map[string]interface{}{"user_id": 12.0, "tenant": "acme"}) {
+		t.Error("The fields should be encoded in JSON:", string(data))
+	}
+
+	var out bytes.Buffer
+
+	slog.New(slog.NewJSONHandler(&out, nil)).Info("failure", "error", err)
+
+	if !strings.Contains(out.String(), `"fields":{"tenant":"acme","user_id":12}`) {
+		t.Error("The fields should be logged:", out.String())
+	}
+}
+
+func TestGetFieldAs(t *testing.T) {
+	cause := &MyError{}
+	_ = cause.Init(cause, "cause", nil, nil, 0)
+	_ = cause.WithFields(map[string]interface{}{"user_id": 12, "tenant": "acme"})
+
+	err := DecorateError(cause).WithField("tenant", "other")
+
+	if value, ok := GetFieldAs[int](err, "user_id"); !ok || (value != 12) {
+		t.Error("The field of the cause should be found:", value)
+	}
+
+	if value, ok := GetFieldAs[string](err, "tenant"); !ok || (value != "other") {
+		t.Error("The field of the error should hide the field of the cause:", value)
+	}
+
+	if value, ok := GetFieldAs[string](err, "user_id"); ok || (value != "") {
+		t.Error("A field with another type should not be found:", value)
+	}
+
+	if _, ok := GetFieldAs[int](fmt.Errorf("wrapped: %w", errors.New("error")), "user_id"); ok {
+		t.Error("A field should not be found in basic errors")
+	}
+}
@@ -0,0 +1,248 @@
+package goerrors
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Separator printed after a stack trace
+const traceSeparator = "------------------------------------------------------------------------------"
+
+var (
+	// Tells for each error type if it has its own `Error` method
+	ownErrorMethods sync.Map
+
+	// Code pointers of the `Error` methods defined in this package
+	packageErrorMethods = []uintptr{
+		reflect.ValueOf((*GoError).Error).Pointer(),
+		reflect.ValueOf((*MultiError).Error).Pointer(),
+	}
+)
+
+// Format implements the `fmt.Formatter` interface, the supported verbs are:
+//
+//	%s, %v  the error on one line (name, message and cause), or the result of its own `Error` method if it has one
+//	%q      the same text as %s but quoted
+//	%+v     the full report with data, stack trace and the cause chain, whatever the debug mode
+//	%#v     a Go-syntax representation of the error
+func (goErr *GoError) Format(s fmt.State, verb rune) {
+	err := goErr.getReference()
+
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			writeReport(s, err)
+
+			return
+		}
+
+		if s.Flag('#') {
+			writeGoSyntax(s, err)
+
+			return
+		}
+
+		_, _ = io.WriteString(s, shortText(err))
+	case 's':
+		_, _ = io.WriteString(s, shortText(err))
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", shortText(err))
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(%s)", verb, oneLine(err))
+	}
+}
+
+// Get the text of the error for the %s and %v verbs.
+func shortText(err IError) string {
+	if hasOwnErrorMethod(err) {
+		return err.Error()
+	}
+
+	return oneLine(err)
+}
+
+// Tells if the error type defines its own `Error` method, instead of the one promoted from the types of this package.
+func hasOwnErrorMethod(err error) bool {
+	errType := reflect.TypeOf(err)
+
+	if res, ok := ownErrorMethods.Load(errType); ok {
+		return res.(bool)
+	}
+
+	res := findOwnErrorMethod(errType)
+	ownErrorMethods.Store(errType, res)
+
+	return res
+}
+
+// Find the type which defines the `Error` method of the type `errType`, through the embedded fields.
+func findOwnErrorMethod(errType reflect.Type) bool {
+	method, ok := errType.MethodByName("Error")
+	if !ok {
+		return false
+	}
+
+	pc := method.Func.Pointer()
+
+	for _, packageMethod := range packageErrorMethods {
+		if pc == packageMethod {
+			return false
+		}
+	}
+
+	// A promoted method is called through a wrapper generated by the compiler
+	if file, _ := runtime.FuncForPC(pc).FileLine(pc); file != "<autogenerated>" {
+		return true
+	}
+
+	structType := errType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return true
+	}
+
+	// The method is promoted from the embedded field which has it
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() != reflect.Ptr {
+			fieldType = reflect.PtrTo(fieldType)
+		}
+
+		if _, found := fieldType.MethodByName("Error"); found {
+			return findOwnErrorMethod(fieldType)
+		}
+	}
+
+	return true
+}
+
+// Get the error on one line.
+func oneLine(err IError) string {
+	parts := []string{err.GetName()}
+
+	if message := err.GetMessage(); message != "" {
+		parts = append(parts, message)
+	}
+
+	if source := err.GetSource(); source != nil {
+		if ierr, ok := source.(IError); ok && !hasOwnErrorMethod(ierr) {
+			parts = append(parts, oneLine(ierr))
+		} else {
+			parts = append(parts, strings.ReplaceAll(source.Error(), "\n", " "))
+		}
+	}
+
+	return strings.Join(parts, ": ")
+}
+
+// Write the full report of the error and its cause chain.
+func writeReport(out io.Writer, err IError) {
+	if message := err.GetMessage(); message != "" {
+		_, _ = fmt.Fprintf(out, "%s: %s\n", err.GetName(), message)
+	} else {
+		_, _ = fmt.Fprintln(out, err.GetName())
+	}
+
+	if data := err.GetData(); data != nil {
+		_, _ = fmt.Fprintln(out, data)
+	}
+
+	writeFields(out, err.Fields())
+
+	if serr, ok := err.(IStandardError); ok {
+		writeInfos(out, serr.Infos())
+	}
+
+	writeTrace(out, err.StackTrace(), err.RaiseHistory(), err.LauncherStack())
+
+	source := err.GetSource()
+	if source == nil {
+		return
+	}
+
+	_, _ = fmt.Fprint(out, "Caused by: ")
+
+	if ierr, ok := source.(IError); ok {
+		writeReport(out, ierr)
+	} else {
+		_, _ = fmt.Fprintln(out, source.Error())
+	}
+}
+
+// Write a Go-syntax representation of the error.
+func writeGoSyntax(out io.Writer, err IError) {
+	_, _ = fmt.Fprintf(out, "&%s{Name:%q, Message:%q, Data:%#v, Source:%#v, StackTrace:%#v, RaiseHistory:%#v}",
+		strings.TrimPrefix(fmt.Sprintf("%T", err), "*"),
+		err.GetName(),
+		err.GetMessage(),
+		err.GetData(),
+		err.GetSource(),
+		err.StackTrace(),
+		err.RaiseHistory())
+}
+
+// Write the stack trace entries, the raise history and the stack trace of the goroutine launcher, followed by a
+// separator if they are not empty.
+func writeTrace(out io.Writer, trace, raises, launch []Frame) {
+	for _, entry := range trace {
+		_, _ = fmt.Fprintln(out, "   ", entry)
+	}
+
+	if len(raises) > 0 {
+		_, _ = fmt.Fprintln(out, "Raised at:")
+
+		for _, entry := range raises {
+			_, _ = fmt.Fprintln(out, "   ", entry)
+		}
+	}
+
+	if len(launch) > 0 {
+		_, _ = fmt.Fprintln(out, "Goroutine launched at:")
+
+		for _, entry := range launch {
+			_, _ = fmt.Fprintln(out, "   ", entry)
+		}
+	}
+
+	if (len(trace) > 0) || (len(raises) > 0) || (len(launch) > 0) {
+		_, _ = fmt.Fprintln(out, traceSeparator)
+	}
+}
+
+// Write the informations added on a standard error.
+func writeInfos(out io.Writer, infos []Info) {
+	if len(infos) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(out, "Infos:")
+
+	for _, info := range infos {
+		_, _ = fmt.Fprintf(out, "  - %s\n", strings.ReplaceAll(info.String(), "\n", "\n    "))
+	}
+}
+
+// Write the fields of an error in the order of their keys.
+func writeFields(out io.Writer, fields map[string]interface{}) {
+	if len(fields) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(out, "Fields:")
+
+	for _, key := range sortedKeys(fields) {
+		_, _ = fmt.Fprintf(out, "  %s: %v\n", key, fields[key])
+	}
+}
@@ -0,0 +1,127 @@
+package goerrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Error with its own `Error` method
+type tCustomTextError struct {
+	GoError
+
+	ID int
+}
+
+func (e *tCustomTextError) Error() string {
+	return fmt.Sprintf("custom %d", e.ID)
+}
+
+// Error which gets its own `Error` method from an embedded error
+type tEmbeddedCustomTextError struct{ tCustomTextError }
+
+func TestFormatOwnErrorMethod(t *testing.T) {
+	err := &tCustomTextError{ID: 7}
+	_ = err.Init(err, "message", nil, nil, 0)
+
+	if res := fmt.Sprint(err); res != "custom 7" {
+		t.Error("The own Error method should be used:", res)
+	}
+
+	if res := fmt.Sprintf("%s %q", err, err); res != `custom 7 "custom 7"` {
+		t.Error("The own Error method should be used for all the short verbs:", res)
+	}
+
+	if res := fmt.Sprintf("%+v", err); !strings.HasPrefix(res, "github.com/corebreaker/goerrors.tCustomTextError: message") {
+		t.Error("The full report should be kept:", res)
+	}
+
+	embedded := &tEmbeddedCustomTextError{tCustomTextError{ID: 8}}
+	_ = embedded.Init(embedded, "message", nil, nil, 0)
+
+	if res := fmt.Sprint(embedded); res != "custom 8" {
+		t.Error("The own Error method of an embedded error should be used:", res)
+	}
+
+	if res := fmt.Sprint(DecorateError(err)); res != "github.com/corebreaker/goerrors.StandardError: custom 7" {
+		t.Error("The own Error method of a source should be used:", res)
+	}
+
+	if hasOwnErrorMethod(MakeError("")) || hasOwnErrorMethod(&UserNotFoundError{}) || hasOwnErrorMethod(MakeMultiError("")) {
+		t.Error("The Error methods of this package should not be own methods")
+	}
+}
+
+func TestFormatOneLine(t *testing.T) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	cause := &MyError{}
+	_ = cause.Init(cause, "cause", nil, nil, 0)
+
+	err := DecorateErrorWithDatas(cause, 0, "data", "message %d", 1)
+
+	const expected = "github.com/corebreaker/goerrors.StandardError: message 1: github.com/corebreaker/goerrors.MyError: cause"
+
+	if res := fmt.Sprintf("%v", err); res != expected {
+		t.Error("Bad one-line format:", res)
+	}
+
+	if res := fmt.Sprintf("%s", err); res != expected {
+		t.Error("Bad string format:", res)
+	}
+
+	if res := fmt.Sprintf("%q", err); res != fmt.Sprintf("%q", expected) {
+		t.Error("Bad quoted format:", res)
+	}
+
+	if res := fmt.Sprintf("%v", DecorateError(errors.New("line 1\nline 2"))); res != "github.com/corebreaker/goerrors.StandardError: line 1 line 2" {
+		t.Error("Bad one-line format with a basic source:", res)
+	}
+
+	if res := fmt.Sprintf("%d", MakeError("")); res != "%!d(github.com/corebreaker/goerrors.StandardError)" {
+		t.Error("Bad format with an unsupported verb:", res)
+	}
+}
+
+func TestFormatReport(t *testing.T) {
+	SetDebug(true)
+
+	cause := &MyError{}
+	_ = cause.Init(cause, "", nil, errors.New("cause"), 0)
+
+	err := DecorateErrorWithDatas(cause, 0, "data", "message")
+
+	SetDebug(false)
+
+	res := fmt.Sprintf("%+v", err)
+	lines := strings.Split(res, "\n")
+
+	if (lines[0] != "github.com/corebreaker/goerrors.StandardError: message") || (lines[1] != "data") {
+		t.Error("Bad header in the report:", res)
+	}
+
+	if strings.Count(res, traceSeparator) != 2 {
+		t.Error("The report should contain the stack traces of the cause chain:", res)
+	}
+
+	if !strings.Contains(res, "Caused by: github.com/corebreaker/goerrors.MyError\n") || !strings.Contains(res, "Caused by: cause\n") {
+		t.Error("The report should contain the cause chain:", res)
+	}
+
+	if res := fmt.Sprintf("%+v", MakeError("message")); res != "github.com/corebreaker/goerrors.StandardError: message\n" {
+		t.Error("Bad report without stack trace:", res)
+	}
+}
+
+func TestFormatGoSyntax(t *testing.T) {
+	res := fmt.Sprintf("%#v", MakeErrorWithDatas(0, 12, "message"))
+
+	const expected = `&goerrors.StandardError{Name:"github.com/corebreaker/goerrors.StandardError", Message:"message", Data:12, ` +
+		`Source:<nil>, StackTrace:[]goerrors.Frame(nil), RaiseHistory:[]goerrors.Frame(nil)}`
+
+	if res != expected {
+		t.Error("Bad Go-syntax format:", res)
+	}
+}
@@ -9,7 +9,7 @@ var (
 	logFatal                           = log.Fatal
 	uncatchedErrorHandler ErrorHandler = func(err IError) error {
 		if err != nil {
-			logFatal(err)
+			logFatal(err.Error())
 		}
 
 		return nil
@@ -42,16 +42,19 @@ func CheckedMain(handler MainHandler) {
 			ierr = DecorateError(err)
 		}
 
+		uncaughtHooks.fire(ierr)
+
 		cerr := uncatchedErrorHandler(ierr)
 		if cerr != nil {
-			logFatal(cerr)
+			logFatal(cerr.Error())
 		}
 	}()
 
 	err := handler()
 
 	if err != nil {
-		logFatal(err)
+		uncaughtHooks.fire(toIError(err))
+		logFatal(err.Error())
 	}
 }
 
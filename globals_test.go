@@ -2,6 +2,8 @@ package goerrors
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -41,3 +43,36 @@ func TestCheckedMain(t *testing.T) {
 		return nil
 	})
 }
+
+func TestCheckedMainTrace(t *testing.T) {
+	defer SetDebug(GetDebug())
+	defer func(old func(...interface{})) { logFatal = old }(logFatal)
+
+	var outputs []string
+
+	logFatal = func(v ...interface{}) { outputs = append(outputs, fmt.Sprint(v...)) }
+
+	defer SetUncatchedErrorHandler(SetUncatchedErrorHandler(func(err IError) error { return err }))
+
+	SetDebug(true)
+
+	CheckedMain(func() error {
+		return MakeError("returned")
+	})
+
+	CheckedMain(func() error {
+		Raise("raised")
+
+		return nil
+	})
+
+	if len(outputs) != 2 {
+		t.Fatal("Bad number of outputs:", outputs)
+	}
+
+	for _, output := range outputs {
+		if !strings.Contains(output, "TestCheckedMainTrace") || !strings.Contains(output, traceSeparator) {
+			t.Error("The output should contain the stack trace in debug mode:", output)
+		}
+	}
+}
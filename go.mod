@@ -1,5 +1,5 @@
 module github.com/corebreaker/goerrors
 
-go 1.12
+go 1.21
 
 require github.com/google/uuid v1.1.1
@@ -0,0 +1,169 @@
+package goerrors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Go runs the function `fn` in a new goroutine.
+// A panic in this goroutine is recovered and converted into an error, then the error raised or returned by `fn` is
+// passed to the uncatched error handler (see `SetUncatchedErrorHandler`).
+// In debug mode, the stack trace of the caller of `Go` is added to the error (see `IError.LauncherStack`).
+func Go(fn func() error) {
+	launch(fn, func(err IError) {
+		if err == nil {
+			return
+		}
+
+		uncaughtHooks.fire(err)
+
+		if handler := uncatchedErrorHandler; handler != nil {
+			_ = handler(err)
+		}
+	}, 1)
+}
+
+// GoWithHandler is like `Go` but the error is passed to the handler `handler`.
+// If the handler returns an error, this error is passed to the uncatched error handler.
+// A nil handler is rejected before launching the goroutine, by raising an error in the caller.
+func GoWithHandler(fn func() error, handler ErrorHandler) {
+	if handler == nil {
+		Raise("GoWithHandler needs an error handler")
+	}
+
+	launch(fn, func(err IError) {
+		if err == nil {
+			return
+		}
+
+		cerr := handler(err)
+		if cerr == nil {
+			return
+		}
+
+		uncaught := toIError(cerr)
+		uncaughtHooks.fire(uncaught)
+
+		if uncatched := uncatchedErrorHandler; uncatched != nil {
+			_ = uncatched(uncaught)
+		}
+	}, 1)
+}
+
+// Group is a collection of goroutines which are protected against panics like with `Go`.
+// The zero value is a valid group without context.
+type Group struct {
+	wg     sync.WaitGroup // Running goroutines
+	cancel func()         // Cancellation of the group context, it may be nil
+	mutex  sync.Mutex     // Guard for errors
+	errs   []error        // Errors of goroutines
+}
+
+// NewGroup makes a group with a context derived from `ctx`.
+// The returned context is canceled when a goroutine of the group fails or when `Wait` returns.
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go runs the function `fn` in a new goroutine of the group.
+// The error raised or returned by `fn` will be returned by `Wait`.
+func (g *Group) Go(fn func() error) {
+	g.wg.Add(1)
+
+	launch(fn, func(err IError) {
+		defer g.wg.Done()
+
+		if err == nil {
+			return
+		}
+
+		g.mutex.Lock()
+		g.errs = append(g.errs, err)
+		g.mutex.Unlock()
+
+		if g.cancel != nil {
+			g.cancel()
+		}
+	}, 1)
+}
+
+// Wait waits for all goroutines of the group.
+// It returns nil if no goroutine has failed, the error if only one goroutine has failed,
+// otherwise a multi-error with the errors of all failed goroutines.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+
+	if g.cancel != nil {
+		g.cancel()
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	switch len(g.errs) {
+	case 0:
+		return nil
+	case 1:
+		return g.errs[0]
+	default:
+		return CombineErrors(g.errs...)
+	}
+}
+
+// Run the function `fn` in a new goroutine, then call `done` with the error raised or returned by `fn`.
+// The function `done` is always called, with nil if there is no error.
+func launch(fn func() error, done func(err IError), pruneLevels uint) {
+	var launcher *tStack
+
+	if GetDebug() {
+		launcher = captureStack(pruneLevels + 1)
+	}
+
+	go func() {
+		var err error
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				recoveredError, ok := recovered.(error)
+				if ok {
+					err = recoveredError
+				} else {
+					err = fmt.Errorf("error: %s", recovered)
+				}
+			}
+
+			if err == nil {
+				done(nil)
+
+				return
+			}
+
+			ierr := toIError(err)
+
+			// The stack trace is added to a copy, the returned error may be shared (like a package-level error)
+			if launcher != nil {
+				var inner *GoError
+
+				ierr, inner = ierr.getGoError().copyError()
+				inner.launch = launcher.Frames()
+			}
+
+			done(ierr)
+		}()
+
+		err = fn()
+	}()
+}
+
+// Convert an error into an IError, the error is decorated if it's not an IError
+func toIError(err error) IError {
+	ierr, ok := err.(IError)
+	if !ok {
+		ierr = DecorateError(err)
+	}
+
+	return ierr
+}
@@ -0,0 +1,178 @@
+package goerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGo(t *testing.T) {
+	errs := make(chan IError, 1)
+
+	old := SetUncatchedErrorHandler(func(err IError) error {
+		errs <- err
+
+		return nil
+	})
+
+	defer SetUncatchedErrorHandler(old)
+
+	Go(func() error {
+		Raise("raised")
+
+		return nil
+	})
+
+	if err := <-errs; err.GetMessage() != "raised" {
+		t.Error("The raised error should be passed to the uncatched error handler:", err)
+	}
+
+	Go(func() error {
+		return nil
+	})
+
+	Go(func() error {
+		return errors.New("returned")
+	})
+
+	if err := <-errs; err.GetSource().Error() != "returned" {
+		t.Error("The returned error should be passed to the uncatched error handler:", err)
+	}
+}
+
+func TestGoWithHandler(t *testing.T) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	errs := make(chan IError, 1)
+	uncatched := make(chan IError, 1)
+
+	old := SetUncatchedErrorHandler(func(err IError) error {
+		uncatched <- err
+
+		return nil
+	})
+
+	defer SetUncatchedErrorHandler(old)
+
+	handler := func(err IError) error {
+		errs <- err
+
+		if source := err.GetSource(); (source != nil) && (source.Error() == "error: uncatched") {
+			return errors.New("handler error")
+		}
+
+		return nil
+	}
+
+	GoWithHandler(func() error {
+		panic("value")
+	}, handler)
+
+	err := <-errs
+	if err.GetSource().Error() != "error: value" {
+		t.Error("A panic with a value should be converted into an error:", err)
+	}
+
+	if launch := err.LauncherStack(); (len(launch) == 0) || (launch[0].Function != "TestGoWithHandler") {
+		t.Error("The error should contain the stack trace of the launcher:", launch)
+	}
+
+	if report := fmt.Sprintf("%+v", err); !strings.Contains(report, "Goroutine launched at:\n    github.com/corebreaker/goerrors.TestGoWithHandler") {
+		t.Error("The stack trace of the launcher should be printed:", report)
+	}
+
+	for i := 0; i < 3; i++ {
+		GoWithHandler(func() error {
+			return errSentinel
+		}, handler)
+
+		if err := <-errs; (err == errSentinel) || !errors.Is(err, errSentinel) || (len(err.LauncherStack()) == 0) {
+			t.Error("The launcher stack trace should be added to a copy of the returned error:", err)
+		}
+	}
+
+	if len(errSentinel.LauncherStack()) != 0 {
+		t.Error("The returned error should not be modified:", errSentinel.LauncherStack())
+	}
+
+	// A customized error which is not a standard error gets the stack trace of the launcher too
+	GoWithHandler(func() error {
+		err := &MyError{}
+
+		return err.Init(err, "custom", nil, nil, 0)
+	}, handler)
+
+	if err := <-errs; len(err.LauncherStack()) == 0 {
+		t.Error("The launcher stack trace should be added to a customized error:", err)
+	}
+
+	if Try(func(IError) error {
+		GoWithHandler(func() error { return nil }, nil)
+
+		return nil
+	}, func(err IError) error { return err }, nil) == nil {
+		t.Error("A nil handler should be rejected")
+	}
+
+	GoWithHandler(func() error {
+		return nil
+	}, handler)
+
+	GoWithHandler(func() error {
+		panic("uncatched")
+	}, handler)
+
+	<-errs
+
+	if err := <-uncatched; err.GetSource().Error() != "handler error" {
+		t.Error("The error of the handler should be passed to the uncatched error handler:", err)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	var group Group
+
+	group.Go(func() error {
+		return nil
+	})
+
+	if group.Wait() != nil {
+		t.Error("A group without failure should not return an error")
+	}
+
+	group.Go(func() error {
+		Raise("raised")
+
+		return nil
+	})
+
+	if err, ok := group.Wait().(IError); !ok || (err.GetMessage() != "raised") {
+		t.Error("The group should return the error of the failed goroutine:", err)
+	}
+}
+
+func TestGroupWithContext(t *testing.T) {
+	group, ctx := NewGroup(context.Background())
+
+	group.Go(func() error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	group.Go(func() error {
+		return errors.New("first")
+	})
+
+	err, ok := group.Wait().(IMultiError)
+	if !ok || (err.Len() != 2) || !errors.Is(err, context.Canceled) {
+		t.Error("The group should return the errors of all failed goroutines:", err)
+	}
+
+	if ctx.Err() == nil {
+		t.Error("The group context should be canceled")
+	}
+}
@@ -0,0 +1,154 @@
+package goerrors
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// Hook is a function called on an event of the error lifecycle (see `OnCreate`, `OnRaise`, `OnCatch` and
+// `OnUncaught`). A panic in a hook is discarded, so a hook can't break the raise and catch mechanism.
+// The errors created, raised or caught by a hook don't call the hooks, so a hook can create errors without recursion.
+type Hook func(err IError)
+
+var (
+	// Hooks called when an error is initialized
+	createHooks tHooks
+
+	// Hooks called when an error is raised
+	raiseHooks tHooks
+
+	// Hooks called when an error is passed to a catch handler
+	catchHooks tHooks
+
+	// Hooks called when an error is not caught
+	uncaughtHooks tHooks
+
+	// Goroutines which are calling hooks, by goroutine ID
+	hookingGoroutines sync.Map
+)
+
+// Registered hook, it's a pointer so that the same function can be registered twice
+type tHook struct {
+	fn Hook // Called function
+}
+
+// List of hooks, the list is copied on each change so that the hooks are called without lock
+type tHooks struct {
+	mutex sync.Mutex               // Guard for changes
+	list  atomic.Pointer[[]*tHook] // Registered hooks
+}
+
+// Register the hook `fn`, and return the function which unregisters it
+func (hooks *tHooks) add(fn Hook) func() {
+	hook := &tHook{fn: fn}
+
+	hooks.mutex.Lock()
+	defer hooks.mutex.Unlock()
+
+	var list []*tHook
+
+	if current := hooks.list.Load(); current != nil {
+		list = append(list, *current...)
+	}
+
+	list = append(list, hook)
+	hooks.list.Store(&list)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			hooks.remove(hook)
+		})
+	}
+}
+
+// Unregister the hook `hook`
+func (hooks *tHooks) remove(hook *tHook) {
+	hooks.mutex.Lock()
+	defer hooks.mutex.Unlock()
+
+	current := hooks.list.Load()
+	if current == nil {
+		return
+	}
+
+	list := make([]*tHook, 0, len(*current))
+
+	for _, registered := range *current {
+		if registered != hook {
+			list = append(list, registered)
+		}
+	}
+
+	hooks.list.Store(&list)
+}
+
+// Call the registered hooks with the error `err`
+func (hooks *tHooks) fire(err IError) {
+	list := hooks.list.Load()
+	if (list == nil) || (len(*list) == 0) {
+		return
+	}
+
+	// The events of the errors used in a hook don't call the hooks, else a hook which creates an error loops forever
+	goroutine := goroutineID()
+	if _, hooking := hookingGoroutines.LoadOrStore(goroutine, true); hooking {
+		return
+	}
+
+	defer hookingGoroutines.Delete(goroutine)
+
+	for _, hook := range *list {
+		callHook(hook.fn, err)
+	}
+}
+
+// Get the ID of the current goroutine, from the header of its stack trace ("goroutine 12 [running]:")
+func goroutineID() uint64 {
+	var buf [64]byte
+
+	header := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if end := bytes.IndexByte(header, ' '); end >= 0 {
+		header = header[:end]
+	}
+
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+
+	return id
+}
+
+// Call the hook `fn`, a panic in the hook is discarded
+func callHook(fn Hook, err IError) {
+	defer DiscardPanic()
+
+	fn(err)
+}
+
+// OnCreate registers a hook called when an error is initialized (by `Init`, `InitWithCode`, `MakeError`,
+// `DecorateError`, ...). It returns the function which unregisters the hook.
+func OnCreate(hook Hook) func() {
+	return createHooks.add(hook)
+}
+
+// OnRaise registers a hook called when an error is raised, with the raised copy of the error.
+// It returns the function which unregisters the hook.
+func OnRaise(hook Hook) func() {
+	return raiseHooks.add(hook)
+}
+
+// OnCatch registers a hook called when an error is passed to a catch handler (by `Catch`, `Try`, `TryCatch`, ...).
+// It returns the function which unregisters the hook.
+func OnCatch(hook Hook) func() {
+	return catchHooks.add(hook)
+}
+
+// OnUncaught registers a hook called when an error is not caught, that's when it ends `CheckedMain` (or
+// `CheckedMainWithOptions`), or when it's passed to the uncatched error handler by `Go`.
+// It returns the function which unregisters the hook.
+func OnUncaught(hook Hook) func() {
+	return uncaughtHooks.add(hook)
+}
@@ -0,0 +1,226 @@
+package goerrors
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Register a hook which records the errors, and unregister it at the end of the test
+func recordHook(t *testing.T, register func(Hook) func()) *[]IError {
+	var (
+		mutex sync.Mutex
+		list  []IError
+	)
+
+	unregister := register(func(err IError) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		list = append(list, err)
+	})
+
+	t.Cleanup(unregister)
+
+	return &list
+}
+
+func TestOnCreate(t *testing.T) {
+	created := recordHook(t, OnCreate)
+
+	err := MakeError("error")
+	serr := &MyStandardError{}
+	_ = serr.InitWithCode(serr, 12, "standard", nil, nil, 0)
+
+	if (len(*created) != 2) || ((*created)[0] != err) || ((*created)[1] != IError(serr)) {
+		t.Fatal("The hook should be called on initialization:", *created)
+	}
+
+	if (*created)[1].(IStandardError).GetCode() != 12 {
+		t.Error("The hook should be called once the error code is set")
+	}
+
+	_ = serr.InitWithCode(serr, 12, "standard", nil, nil, 0)
+
+	if _, ok := (*created)[2].(*InitError); !ok || (len(*created) != 3) {
+		t.Error("The hook should be called once for an initialization error:", *created)
+	}
+}
+
+func TestOnRaiseAndCatch(t *testing.T) {
+	raised := recordHook(t, OnRaise)
+	caught := recordHook(t, OnCatch)
+
+	err := MakeError("error")
+
+	_ = Try(func(IError) error {
+		err.Raise()
+
+		return nil
+	}, func(IError) error { return nil }, nil)
+
+	if (len(*raised) != 1) || !err.IsParentOf((*raised)[0]) {
+		t.Error("The hook should be called with the raised error:", *raised)
+	}
+
+	if (len(*caught) != 1) || ((*caught)[0] != (*raised)[0]) {
+		t.Error("The hook should be called with the caught error:", *caught)
+	}
+
+	func() {
+		defer err.Catch(nil, func(IError) error { return nil }, nil)
+
+		RaiseError(errors.New("error"))
+	}()
+
+	func() {
+		defer Catch(nil, func(IError) error { return nil }, nil)
+
+		Raise("error")
+	}()
+
+	if (len(*raised) != 3) || (len(*caught) != 3) {
+		t.Error("The hooks should be called for all raise and catch calls:", len(*raised), len(*caught))
+	}
+}
+
+func TestOnUncaught(t *testing.T) {
+	uncaught := recordHook(t, OnUncaught)
+
+	_ = RunMain(func() error { panic("error") }, WithOutput(new(bytes.Buffer)))
+
+	if len(*uncaught) != 1 {
+		t.Fatal("The hook should be called when an error ends the program:", *uncaught)
+	}
+
+	if (*uncaught)[0].GetSource().Error() != "error: error" {
+		t.Error("Bad uncaught error:", (*uncaught)[0])
+	}
+}
+
+func TestHookUnregister(t *testing.T) {
+	var calls int32
+
+	hook := func(IError) { atomic.AddInt32(&calls, 1) }
+
+	unregister1 := OnCreate(hook)
+	unregister2 := OnCreate(hook)
+
+	_ = MakeError("error")
+
+	unregister1()
+	unregister1()
+
+	_ = MakeError("error")
+
+	unregister2()
+
+	_ = MakeError("error")
+
+	if calls != 3 {
+		t.Error("Bad number of hook calls:", calls)
+	}
+}
+
+func TestHookPanic(t *testing.T) {
+	defer OnRaise(func(IError) { panic("hook") })()
+	defer OnCatch(func(IError) { Raise("hook") })()
+
+	caught := recordHook(t, OnCatch)
+
+	var handled IError
+
+	err := Try(func(IError) error {
+		Raise("error")
+
+		return nil
+	}, func(err IError) error {
+		handled = err
+
+		return nil
+	}, nil)
+
+	if (handled == nil) || (err != handled) || (handled.GetMessage() != "error") {
+		t.Error("A panic in a hook should not break the raise and catch mechanism:", err, handled)
+	}
+
+	if len(*caught) != 1 {
+		t.Error("A panic in a hook should not prevent the other hooks to be called")
+	}
+}
+
+func TestHookReentrancy(t *testing.T) {
+	var created, raised []IError
+
+	defer OnCreate(func(err IError) {
+		created = append(created, err)
+
+		// The audit error doesn't call the hooks
+		_ = DecorateError(MakeError("audit of %s", err.GetMessage()))
+	})()
+
+	defer OnRaise(func(err IError) {
+		raised = append(raised, err)
+
+		_ = Try(func(IError) error {
+			Raise("raised in a hook")
+
+			return nil
+		}, nil, nil)
+	})()
+
+	_ = Try(func(IError) error {
+		Raise("error")
+
+		return nil
+	}, nil, nil)
+
+	if (len(created) != 1) || (created[0].GetMessage() != "error") {
+		t.Error("The errors created in a hook should not call the hooks:", created)
+	}
+
+	if (len(raised) != 1) || (raised[0].GetMessage() != "error") {
+		t.Error("The errors raised in a hook should not call the hooks:", raised)
+	}
+
+	_ = MakeError("after")
+
+	if len(created) != 2 {
+		t.Error("The hooks should be called again after a hook:", created)
+	}
+
+	if id := goroutineID(); id == 0 {
+		t.Error("The goroutine ID should be found")
+	}
+}
+
+func TestHooksConcurrency(t *testing.T) {
+	var (
+		wg    sync.WaitGroup
+		calls int32
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			OnCreate(func(IError) { atomic.AddInt32(&calls, 1) })()
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			_ = MakeError("error")
+		}()
+	}
+
+	wg.Wait()
+
+	if list := createHooks.list.Load(); (list != nil) && (len(*list) != 0) {
+		t.Error("All hooks should be unregistered:", len(*list))
+	}
+}
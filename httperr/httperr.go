@@ -0,0 +1,180 @@
+// Package httperr - HTTP middleware which converts the errors raised by handlers into RFC 7807 problem responses.
+package httperr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/corebreaker/goerrors"
+)
+
+// ProblemContentType is the content type of problem responses (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// Problem is the body of a problem response (RFC 7807), with extension members for the error
+type Problem struct {
+	Type     string           `json:"type"`                // URI of the problem type
+	Title    string           `json:"title"`               // Short summary of the problem type
+	Status   int              `json:"status"`              // HTTP status code
+	Detail   string           `json:"detail,omitempty"`    // Explanation of this occurrence of the problem
+	Instance string           `json:"instance,omitempty"`  // URI of this occurrence of the problem
+	Name     string           `json:"name,omitempty"`      // Error name
+	Code     int64            `json:"code,omitempty"`      // Error code
+	CodeName string           `json:"code_name,omitempty"` // Name of the registered error code
+	Trace    []goerrors.Frame `json:"trace,omitempty"`     // Stack trace, only in debug mode
+}
+
+// StatusMapper maps errors to HTTP status codes.
+// An error is mapped with its code first, then with its type hierarchy, else the default status is used.
+// The causes of the error are used too, so a decorated error is mapped like its source.
+type StatusMapper struct {
+	defaultStatus int                  // Status used when no mapping matches
+	statuses      goerrors.ErrorMapper // Mapping of error codes and error types
+}
+
+// NewStatusMapper makes a status mapper which maps all errors to the status 500 (Internal Server Error)
+func NewStatusMapper() *StatusMapper {
+	return &StatusMapper{
+		defaultStatus: http.StatusInternalServerError,
+	}
+}
+
+// SetDefault defines the status used when no mapping matches
+func (m *StatusMapper) SetDefault(status int) *StatusMapper {
+	m.defaultStatus = status
+
+	return m
+}
+
+// MapCode maps the error code `code` to the HTTP status `status`
+func (m *StatusMapper) MapCode(code int64, status int) *StatusMapper {
+	m.statuses.MapCode(code, status)
+
+	return m
+}
+
+// MapError maps the errors which have the type of `err` as parent to the HTTP status `status`.
+// The error `err` is only used for its type, so it can be an uninitialized value like `&NotFoundError{}`.
+// When an error matches several mapped types, the most specific type is used.
+func (m *StatusMapper) MapError(err goerrors.IError, status int) *StatusMapper {
+	m.statuses.MapError(err, status)
+
+	return m
+}
+
+// Status gets the HTTP status for the error `err`
+func (m *StatusMapper) Status(err error) int {
+	if status, found := m.statuses.Map(err); found {
+		return status
+	}
+
+	return m.defaultStatus
+}
+
+// Handler is an HTTP handler which recovers the errors raised by the handler `Next`,
+// and writes them as problem responses (RFC 7807).
+//
+// The stack traces reveal file paths and function names, so `Debug` must only enable the debug mode for trusted
+// requests (authenticated, or from an internal network), never for a header that any client can send.
+// The debug mode enabled by `Debug` is carried by the request context, so only the errors created with the context
+// functions (`MakeErrorContext`, `DecorateErrorContext`, `InitContext`, ...) have a stack trace. The errors created
+// without the request context only have a stack trace in global debug mode.
+type Handler struct {
+	Next    http.Handler                               // Protected handler
+	Mapper  *StatusMapper                              // Status mapping, all errors are mapped to 500 if nil
+	Debug   func(r *http.Request) bool                 // Tells if the debug mode is enabled for a trusted request, it may be nil
+	OnError func(r *http.Request, err goerrors.IError) // Called for each error, for logging for example
+}
+
+// Wrap protects the handler `next` with the status mapping `mapper`
+func Wrap(next http.Handler, mapper *StatusMapper) *Handler {
+	return &Handler{Next: next, Mapper: mapper}
+}
+
+// ServeHTTP implements the `http.Handler` interface
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if (h.Debug != nil) && h.Debug(r) {
+		r = r.WithContext(goerrors.WithDebug(r.Context(), true))
+	}
+
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		// The abort of the handler must be processed by the HTTP server
+		if recovered == http.ErrAbortHandler {
+			panic(recovered)
+		}
+
+		err, ok := recovered.(error)
+		if !ok {
+			err = fmt.Errorf("error: %v", recovered)
+		}
+
+		h.WriteError(w, r, err)
+	}()
+
+	h.Next.ServeHTTP(w, r)
+}
+
+// WriteError writes the error `err` as a problem response,
+// the stack trace is written only if the debug mode is enabled for the request.
+func (h *Handler) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	ierr, ok := err.(goerrors.IError)
+	if !ok {
+		ierr = goerrors.DecorateErrorContext(r.Context(), err)
+	}
+
+	if h.OnError != nil {
+		h.OnError(r, ierr)
+	}
+
+	mapper := h.Mapper
+	if mapper == nil {
+		mapper = NewStatusMapper()
+	}
+
+	problem := MakeProblem(ierr, mapper.Status(ierr))
+	problem.Instance = r.URL.Path
+
+	if !goerrors.GetContextDebug(r.Context()) {
+		problem.Trace = nil
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// MakeProblem makes the problem for the error `err` with the HTTP status `status`
+func MakeProblem(err goerrors.IError, status int) *Problem {
+	res := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.GetMessage(),
+		Name:   err.GetName(),
+		Trace:  err.StackTrace(),
+	}
+
+	// The detail is on one line, a goerrors source would have its multi-line report with `Error`
+	if (res.Detail == "") && (err.GetSource() != nil) {
+		res.Detail = strings.ReplaceAll(fmt.Sprintf("%v", err.GetSource()), "\n", " ")
+	}
+
+	if serr, ok := err.(goerrors.IStandardError); ok {
+		res.Code = serr.GetCode()
+
+		if def := serr.GetCodeDefinition(); def != nil {
+			res.CodeName = def.Name
+		}
+	}
+
+	return res
+}
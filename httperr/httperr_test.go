@@ -0,0 +1,223 @@
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/corebreaker/goerrors"
+)
+
+type NotFoundError struct{ goerrors.GoError }
+
+type UserNotFoundError struct{ NotFoundError }
+
+func newUserNotFound() goerrors.IError {
+	err := &UserNotFoundError{}
+
+	return err.Init(err, "user not found", nil, nil, 0)
+}
+
+var (
+	testCode = goerrors.RegisterCode(4290, "TooManyRequests", "", "too many requests", goerrors.SeverityWarning)
+)
+
+func serve(t *testing.T, handler http.Handler, header string) (*httptest.ResponseRecorder, *Problem) {
+	req := httptest.NewRequest(http.MethodGet, "/users/12", nil)
+	if header != "" {
+		req.Header.Set(header, "1")
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get("Content-Type") != ProblemContentType {
+		return rec, nil
+	}
+
+	var problem Problem
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+
+	return rec, &problem
+}
+
+func TestStatusMapper(t *testing.T) {
+	mapper := NewStatusMapper().
+		MapError(&goerrors.GoError{}, http.StatusBadGateway).
+		MapError(&NotFoundError{}, http.StatusNotFound).
+		MapCode(4290, http.StatusTooManyRequests)
+
+	if status := mapper.Status(newUserNotFound()); status != http.StatusNotFound {
+		t.Error("The most specific type should be used:", status)
+	}
+
+	if status := mapper.Status(goerrors.DecorateError(newUserNotFound())); status != http.StatusNotFound {
+		t.Error("The type of the cause should be used:", status)
+	}
+
+	if status := mapper.Status(goerrors.MakeErrorWithDatas(4290, nil, "")); status != http.StatusTooManyRequests {
+		t.Error("The error code should be used:", status)
+	}
+
+	if status := mapper.Status(goerrors.MakeErrorWithDatas(4291, nil, "")); status != http.StatusBadGateway {
+		t.Error("The root type should be used for unmapped codes:", status)
+	}
+
+	if status := mapper.SetDefault(http.StatusTeapot).Status(errors.New("error")); status != http.StatusTeapot {
+		t.Error("The default status should be used:", status)
+	}
+}
+
+func TestHandlerWithoutError(t *testing.T) {
+	handler := Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), nil)
+
+	if rec, problem := serve(t, handler, ""); (rec.Code != http.StatusNoContent) || (problem != nil) {
+		t.Error("The response of the handler should be kept:", rec.Code)
+	}
+}
+
+func TestHandlerWithRaisedError(t *testing.T) {
+	var logged goerrors.IError
+
+	handler := &Handler{
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			newUserNotFound().Raise()
+		}),
+		Mapper: NewStatusMapper().MapError(&NotFoundError{}, http.StatusNotFound),
+		OnError: func(r *http.Request, err goerrors.IError) {
+			logged = err
+		},
+	}
+
+	rec, problem := serve(t, handler, "")
+	if (rec.Code != http.StatusNotFound) || (problem == nil) {
+		t.Fatal("Bad response:", rec.Code, rec.Body)
+	}
+
+	expected := Problem{
+		Type:     "about:blank",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "user not found",
+		Instance: "/users/12",
+		Name:     "github.com/corebreaker/goerrors/httperr.UserNotFoundError",
+	}
+
+	if (problem.Type != expected.Type) ||
+		(problem.Title != expected.Title) ||
+		(problem.Status != expected.Status) ||
+		(problem.Detail != expected.Detail) ||
+		(problem.Instance != expected.Instance) ||
+		(problem.Name != expected.Name) ||
+		(problem.Trace != nil) {
+		t.Error("Bad problem:", rec.Body)
+	}
+
+	if (logged == nil) || (logged.GetMessage() != "user not found") {
+		t.Error("The error should be passed to the error callback:", logged)
+	}
+}
+
+func TestHandlerWithCode(t *testing.T) {
+	handler := Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testCode.Raise(nil)
+	}), NewStatusMapper().MapCode(4290, http.StatusTooManyRequests))
+
+	rec, problem := serve(t, handler, "")
+	if (rec.Code != http.StatusTooManyRequests) || (problem.Code != 4290) || (problem.CodeName != "TooManyRequests") {
+		t.Error("Bad problem for a code:", rec.Code, rec.Body)
+	}
+}
+
+func TestHandlerWithPanic(t *testing.T) {
+	handler := Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("value")
+	}), nil)
+
+	rec, problem := serve(t, handler, "")
+	if (rec.Code != http.StatusInternalServerError) || (problem.Detail != "error: value") {
+		t.Error("Bad problem for a panic:", rec.Code, rec.Body)
+	}
+}
+
+func TestMakeProblemDetail(t *testing.T) {
+	problem := MakeProblem(goerrors.DecorateError(newUserNotFound()), http.StatusNotFound)
+
+	if problem.Detail != "github.com/corebreaker/goerrors/httperr.UserNotFoundError: user not found" {
+		t.Error("The detail should be the source on one line:", problem.Detail)
+	}
+
+	problem = MakeProblem(goerrors.DecorateError(errors.New("first line\nsecond line")), http.StatusInternalServerError)
+
+	if problem.Detail != "first line second line" {
+		t.Error("The detail of a basic source should be on one line:", problem.Detail)
+	}
+}
+
+// Debug mode for the requests with the debug token, like a request authenticated by a proxy
+func trustedDebug(r *http.Request) bool {
+	return r.Header.Get("X-Debug-Token") == "1"
+}
+
+func TestHandlerDebug(t *testing.T) {
+	handler := &Handler{
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			goerrors.RaiseError(goerrors.MakeErrorContext(r.Context(), "error"))
+		}),
+		Debug: trustedDebug,
+	}
+
+	if _, problem := serve(t, handler, "X-Debug-Token"); len(problem.Trace) == 0 {
+		t.Error("The stack trace should be written in debug mode")
+	}
+
+	if _, problem := serve(t, handler, ""); problem.Trace != nil {
+		t.Error("The stack trace should not be written without debug mode")
+	}
+
+	if _, problem := serve(t, handler, "X-Debug"); problem.Trace != nil {
+		t.Error("The stack trace should not be written for an untrusted request")
+	}
+
+	goerrors.SetDebug(true)
+	defer goerrors.SetDebug(false)
+
+	if _, problem := serve(t, handler, ""); len(problem.Trace) == 0 {
+		t.Error("The stack trace should be written in global debug mode")
+	}
+}
+
+func TestHandlerDebugWithoutContext(t *testing.T) {
+	handler := &Handler{
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			goerrors.RaiseError(goerrors.MakeError("error"))
+		}),
+		Debug: trustedDebug,
+	}
+
+	// The debug mode of the request is not known by an error created without the request context
+	if _, problem := serve(t, handler, "X-Debug-Token"); problem.Trace != nil {
+		t.Error("The stack trace should only be captured for errors created with the request context")
+	}
+}
+
+func TestHandlerAbort(t *testing.T) {
+	handler := Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}), nil)
+
+	defer func() {
+		if recover() != http.ErrAbortHandler {
+			t.Error("The abort of the handler should not be recovered")
+		}
+	}()
+
+	serve(t, handler, "")
+}
@@ -0,0 +1,47 @@
+package goerrors
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// InfoKind is the kind of an information added on a standard error
+type InfoKind int
+
+// Kinds of information
+const (
+	InfoNote         InfoKind = iota // Information added with `AddInfo`
+	InfoRedecoration                 // Information added when a standard error is decorated again
+)
+
+// Info is an information added on a standard error
+type Info struct {
+	Text    string    `json:"text"`              // Text of the information
+	Time    time.Time `json:"time"`              // Moment when the information was added
+	File    string    `json:"file,omitempty"`    // Source file of the call site
+	Line    int       `json:"line,omitempty"`    // Line of the call site
+	Kind    InfoKind  `json:"kind,omitempty"`    // Kind of information
+	Code    int64     `json:"code,omitempty"`    // Error code of a redecoration
+	Message string    `json:"message,omitempty"` // Message of a redecoration
+}
+
+// String formats the information like `text (file:line)`
+func (info Info) String() string {
+	if info.File == "" {
+		return info.Text
+	}
+
+	return fmt.Sprintf("%s (%s:%d)", info.Text, info.File, info.Line)
+}
+
+// Make an information with the call site of the caller
+func makeInfo(kind InfoKind, text string, pruneLevels uint) Info {
+	res := Info{Text: text, Time: time.Now(), Kind: kind}
+
+	if _, file, line, ok := runtime.Caller(int(pruneLevels + 1)); ok {
+		res.File, res.Line = file, line
+	}
+
+	return res
+}
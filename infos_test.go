@@ -0,0 +1,65 @@
+package goerrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestInfoString(t *testing.T) {
+	if (Info{Text: "text"}).String() != "text" {
+		t.Error("An information without call site should be printed as its text")
+	}
+
+	if (Info{Text: "text", File: "file.go", Line: 12}).String() != "text (file.go:12)" {
+		t.Error("An information with call site should be printed with its call site")
+	}
+}
+
+func TestInfosInError(t *testing.T) {
+	err := MakeError("message").AddInfo("first %d", 1)
+	_ = AddInfo(err, "second\nline")
+
+	lines := strings.Split(err.Error(), "\n")
+	if (len(lines) < 4) ||
+		(lines[1] != "Infos:") ||
+		!strings.HasPrefix(lines[2], "  - first 1 (") ||
+		(lines[3] != "  - second") ||
+		!strings.HasPrefix(lines[4], "    line (") {
+		t.Error("The informations should be printed:", err.Error())
+	}
+
+	if res := fmt.Sprintf("%+v", err); !strings.Contains(res, "Infos:\n  - first 1 (") {
+		t.Error("The informations should be in the report:", res)
+	}
+
+	infos := err.Infos()
+	if (len(infos) != 2) || !strings.HasSuffix(infos[1].File, "infos_test.go") || (infos[1].Kind != InfoNote) {
+		t.Error("The call site of the global function should be recorded:", infos)
+	}
+
+	infos[0].Text = ""
+	if err.Infos()[0].Text == "" {
+		t.Error("The informations should be a copy")
+	}
+}
+
+func TestRedecorationInfo(t *testing.T) {
+	err := DecorateErrorWithDatas(errors.New("error"), 1, nil, "first")
+	_ = DecorateErrorWithDatas(err, 2, nil, "second %d", 2)
+
+	infos := err.Infos()
+	if len(infos) != 1 {
+		t.Fatal("The redecoration should add an information:", infos)
+	}
+
+	info := infos[0]
+	if (info.Kind != InfoRedecoration) || (info.Code != 2) || (info.Message != "second 2") {
+		t.Error("Bad redecoration information:", info)
+	}
+
+	if (info.Text != "Redecorated for code=2 and message=second 2") || !strings.HasSuffix(info.File, "infos_test.go") {
+		t.Error("Bad redecoration text or call site:", info)
+	}
+}
@@ -0,0 +1,192 @@
+package goerrors
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// JSON representation of an error
+type tJSONError struct {
+	Name    string                 `json:"name,omitempty"`    // Error name, empty for a basic go error
+	Message string                 `json:"message,omitempty"` // Error message
+	Code    int64                  `json:"code,omitempty"`    // Error code of a standard error
+	Data    interface{}            `json:"data,omitempty"`    // Custom data
+	Fields  map[string]interface{} `json:"fields,omitempty"`  // Key/value fields
+	Infos   []Info                 `json:"infos,omitempty"`   // Additionnal informations of a standard error
+	Parents []string               `json:"parents,omitempty"` // Type hierarchy
+	Source  *tJSONError            `json:"source,omitempty"`  // Cause error
+	Errors  []*tJSONError          `json:"errors,omitempty"`  // Aggregated errors of a multi-error
+	Stack   []Frame                `json:"stack,omitempty"`   // Stack trace
+	Raises  []Frame                `json:"raises,omitempty"`  // Raise history
+	Launch  []Frame                `json:"launch,omitempty"`  // Stack trace of the goroutine launcher
+}
+
+// Make the JSON representation of an error
+func makeJSONError(err error) *tJSONError {
+	if err == nil {
+		return nil
+	}
+
+	ierr, ok := err.(IError)
+	if !ok {
+		return &tJSONError{Message: err.Error()}
+	}
+
+	res := &tJSONError{
+		Name:    ierr.GetName(),
+		Message: ierr.GetMessage(),
+		Data:    ierr.GetData(),
+		Fields:  ierr.Fields(),
+		Parents: ierr.getParents(),
+		Source:  makeJSONError(ierr.GetSource()),
+		Stack:   ierr.StackTrace(),
+		Raises:  ierr.RaiseHistory(),
+		Launch:  ierr.LauncherStack(),
+	}
+
+	if serr, ok := ierr.(IStandardError); ok {
+		res.Code = serr.GetCode()
+		res.Infos = serr.Infos()
+	}
+
+	if merr, ok := ierr.(IMultiError); ok {
+		for _, err := range merr.Errors() {
+			res.Errors = append(res.Errors, makeJSONError(err))
+		}
+	}
+
+	return res
+}
+
+// Convert back the JSON representation into an error
+func (je *tJSONError) toError() error {
+	if je == nil {
+		return nil
+	}
+
+	if je.Name == "" {
+		return errors.New(je.Message)
+	}
+
+	res := &tDecodedError{name: je.Name, parents: je.Parents}
+	_ = res.Init(res, "", nil, nil, 0)
+
+	res.fromJSON(je)
+
+	return res
+}
+
+// MarshalJSON implements the `json.Marshaler` interface,
+// the error is encoded with its name, message, data, type hierarchy, cause chain and stack trace.
+func (goErr *GoError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(makeJSONError(goErr.getReference()))
+}
+
+// UnmarshalJSON implements the `json.Unmarshaler` interface.
+// The type of this error is kept, to get an error with the encoded name and type hierarchy, use `UnmarshalError`.
+// The error must be initialized before (see `Init`), else an `InitError` is returned.
+func (goErr *GoError) UnmarshalJSON(data []byte) error {
+	if err := goErr.checkDecodable(); err != nil {
+		return err
+	}
+
+	var value tJSONError
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	goErr.fromJSON(&value)
+
+	return nil
+}
+
+// Check that this error is initialized, the type of an uninitialized error can't be known when it's decoded
+func (goErr *GoError) checkDecodable() error {
+	if goErr.errType == nil {
+		return makeInitError("The error should be initialized before being decoded from JSON")
+	}
+
+	return nil
+}
+
+// Fill this error from its JSON representation
+func (goErr *GoError) fromJSON(value *tJSONError) {
+	goErr.message = value.Message
+	goErr.data = value.Data
+	goErr.fields = value.Fields
+	goErr.source = value.Source.toError()
+	goErr.trace = makeResolvedStack(value.Stack)
+	goErr.raises = value.Raises
+	goErr.launch = value.Launch
+}
+
+// UnmarshalJSON implements the `json.Unmarshaler` interface, it decodes the error code and the informations too.
+// The error must be initialized before (see `InitWithCode`), else an `InitError` is returned.
+func (se *StandardError) UnmarshalJSON(data []byte) error {
+	if err := se.checkDecodable(); err != nil {
+		return err
+	}
+
+	var value tJSONError
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	se.fromJSON(&value)
+
+	return nil
+}
+
+// Fill this standard error from its JSON representation
+func (se *StandardError) fromJSON(value *tJSONError) {
+	se.GoError.fromJSON(value)
+
+	se.code = value.Code
+	se.infos = value.Infos
+}
+
+// UnmarshalError decodes an error encoded in JSON.
+// The resulting error keeps the encoded name and type hierarchy, so the `IsParentOf` method still works with the
+// error types of the original error.
+func UnmarshalError(data []byte) (IStandardError, error) {
+	var value tJSONError
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	if value.Name == "" {
+		return DecorateError(value.toError()), nil
+	}
+
+	return value.toError().(IStandardError), nil
+}
+
+// Error decoded from JSON
+type tDecodedError struct {
+	StandardError
+
+	name    string   // Error name
+	parents []string // Type hierarchy
+}
+
+// GetName gets the decoded error name
+func (de *tDecodedError) GetName() string {
+	return de.name
+}
+
+// IsParentOf tests if the original type of this error is one of parents of error `err` passed in parameter
+func (de *tDecodedError) IsParentOf(err error) bool {
+	if len(de.parents) == 0 {
+		return false
+	}
+
+	return isParentOf(de.parents[0], err)
+}
+
+// Get the type hierarchy of the original error
+func (de *tDecodedError) getParents() []string {
+	return de.parents
+}
@@ -0,0 +1,220 @@
+package goerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalError(t *testing.T) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	cause := &MyChildError{}
+	_ = cause.Init(cause, "cause", nil, errors.New("origin"), 0)
+
+	err := DecorateErrorWithDatas(cause, 1234, map[string]interface{}{"id": 12.0}, "message")
+	_ = err.AddInfo("info %d", 1)
+
+	data, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+
+	var value map[string]interface{}
+
+	if jerr := json.Unmarshal(data, &value); jerr != nil {
+		t.Fatal(jerr)
+	}
+
+	if (value["name"] != "github.com/corebreaker/goerrors.StandardError") || (value["message"] != "message") || (value["code"] != 1234.0) {
+		t.Error("Bad encoding of the error:", string(data))
+	}
+
+	infos, _ := value["infos"].([]interface{})
+	if (len(infos) != 1) || (infos[0].(map[string]interface{})["text"] != "info 1") {
+		t.Error("Bad encoding of the informations:", value["infos"])
+	}
+
+	if !reflect.DeepEqual(value["data"], map[string]interface{}{"id": 12.0}) {
+		t.Error("Bad encoding of the data:", value["data"])
+	}
+
+	if len(value["stack"].([]interface{})) == 0 {
+		t.Error("The stack trace should be encoded")
+	}
+
+	source := value["source"].(map[string]interface{})
+
+	parents := []interface{}{
+		"github.com/corebreaker/goerrors.MyChildError",
+		"github.com/corebreaker/goerrors.MyError",
+		"github.com/corebreaker/goerrors.GoError",
+	}
+
+	if (source["name"] != "github.com/corebreaker/goerrors.MyChildError") || !reflect.DeepEqual(source["parents"], parents) {
+		t.Error("Bad encoding of the source error:", source)
+	}
+
+	if !reflect.DeepEqual(source["source"], map[string]interface{}{"message": "origin"}) {
+		t.Error("Bad encoding of a basic source error:", source["source"])
+	}
+}
+
+func TestMarshalMultiError(t *testing.T) {
+	data, err := json.Marshal(CombineErrors(errors.New("a"), MakeError("b")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var value struct {
+		Name   string `json:"name"`
+		Errors []struct {
+			Name    string `json:"name"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatal(err)
+	}
+
+	if (value.Name != "github.com/corebreaker/goerrors.MultiError") ||
+		(len(value.Errors) != 2) ||
+		(value.Errors[0].Message != "a") ||
+		(value.Errors[1].Name != "github.com/corebreaker/goerrors.StandardError") ||
+		(value.Errors[1].Message != "b") {
+		t.Error("The aggregated errors should be encoded:", string(data))
+	}
+}
+
+func TestUnmarshalError(t *testing.T) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	cause := &MyChildError{}
+	_ = cause.Init(cause, "cause", 12.0, errors.New("origin"), 0)
+
+	data, jerr := json.Marshal(DecorateErrorWithDatas(cause, 1234, nil, "message").AddInfo("info"))
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+
+	err, jerr := UnmarshalError(data)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+
+	if (err.GetName() != "github.com/corebreaker/goerrors.StandardError") || (err.GetMessage() != "message") || (err.GetCode() != 1234) {
+		t.Error("Bad decoding of the error:", err)
+	}
+
+	if infos := err.Infos(); (len(infos) != 1) || (infos[0].Text != "info") || !strings.HasSuffix(infos[0].File, "json_test.go") {
+		t.Error("Bad decoding of the informations:", infos)
+	}
+
+	if len(err.StackTrace()) == 0 {
+		t.Error("The stack trace should be decoded")
+	}
+
+	if !MakeError("").IsParentOf(err) {
+		t.Error("A standard error should be a parent of a decoded standard error")
+	}
+
+	source, ok := err.GetSource().(IError)
+	if !ok {
+		t.Fatal("The source should be decoded as an error with a type hierarchy:", err.GetSource())
+	}
+
+	if (source.GetName() != "github.com/corebreaker/goerrors.MyChildError") || (source.GetData() != 12.0) {
+		t.Error("Bad decoding of the source error:", source)
+	}
+
+	parent := &MyError{}
+	if !parent.Init(parent, "", nil, nil, 0).IsParentOf(source) || !errors.Is(source, &MyError{}) {
+		t.Error("The decoded error should be in the hierarchy of its original type")
+	}
+
+	if !source.IsParentOf(cause) || source.IsParentOf(MakeError("")) {
+		t.Error("The decoded error should be a parent of errors of its original type only")
+	}
+
+	if (source.GetSource() == nil) || (source.GetSource().Error() != "origin") {
+		t.Error("Bad decoding of a basic source error:", source.GetSource())
+	}
+}
+
+func TestUnmarshalBasicError(t *testing.T) {
+	err, jerr := UnmarshalError([]byte(`{"message": "error"}`))
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+
+	if err.GetSource().Error() != "error" {
+		t.Error("A basic error should be decoded as a decorated error:", err)
+	}
+
+	if _, jerr := UnmarshalError([]byte("{")); jerr == nil {
+		t.Error("A decoding error should be returned")
+	}
+
+	if (&tDecodedError{}).IsParentOf(err) {
+		t.Error("A decoded error without hierarchy can't be a parent")
+	}
+}
+
+func TestUnmarshalIntoError(t *testing.T) {
+	err := &MyError{}
+	_ = err.Init(err, "", nil, nil, 0)
+
+	if jerr := json.Unmarshal([]byte(`{"message": "msg", "data": 1, "source": {"message": "src"}}`), err); jerr != nil {
+		t.Fatal(jerr)
+	}
+
+	if (err.GetName() != "github.com/corebreaker/goerrors.MyError") || (err.GetMessage() != "msg") || (err.GetData() != 1.0) {
+		t.Error("Bad decoding into an existing error:", err)
+	}
+
+	serr := MakeError("")
+
+	if jerr := json.Unmarshal([]byte(`{"message": "msg", "code": 12, "infos": [{"text": "info"}]}`), serr); jerr != nil {
+		t.Fatal(jerr)
+	}
+
+	if (serr.GetMessage() != "msg") || (serr.GetCode() != 12) || (serr.Infos()[0].Text != "info") {
+		t.Error("Bad decoding into an existing standard error:", serr)
+	}
+
+	if json.Unmarshal([]byte("{"), err) == nil || json.Unmarshal([]byte("{"), serr) == nil {
+		t.Error("A decoding error should be returned")
+	}
+}
+
+func TestUnmarshalIntoUninitializedError(t *testing.T) {
+	var (
+		gerr MyError
+		serr MyStandardError
+	)
+
+	for _, target := range []interface{}{&gerr, &serr} {
+		err := json.Unmarshal([]byte(`{"message": "msg"}`), target)
+
+		var initErr *InitError
+
+		if !errors.As(err, &initErr) {
+			t.Error("Decoding into an uninitialized error should fail:", err)
+		}
+	}
+
+	if (gerr.errType != nil) || (gerr.message != "") || (serr.errType != nil) || (serr.message != "") {
+		t.Error("An uninitialized error should not be modified")
+	}
+
+	_ = gerr.Init(&gerr, "", nil, nil, 0)
+
+	if (json.Unmarshal([]byte(`{"message": "msg"}`), &gerr) != nil) || (gerr.GetName() != "github.com/corebreaker/goerrors.MyError") {
+		t.Error("An initialized error should be decoded")
+	}
+}
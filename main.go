@@ -0,0 +1,227 @@
+package goerrors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Function used to exit the process, it's replaced in tests
+var osExit = os.Exit
+
+// OutputFormat is the format used by `CheckedMainWithOptions` to print the error which ends the program
+type OutputFormat int
+
+const (
+	// OutputVerbose prints the full report of the error, like the `%+v` verb
+	OutputVerbose OutputFormat = iota
+
+	// OutputShort prints the error on one line, like the `%v` verb
+	OutputShort
+
+	// OutputJSON prints the error as a JSON object, like `json.Marshal`
+	OutputJSON
+)
+
+// ContextMainHandler is the handler for main function used with the CheckedMainContext function,
+// the context is cancelled when the program is interrupted by a signal (see `WithSignals`)
+type ContextMainHandler func(ctx context.Context) error
+
+// MainOption is an option for the `CheckedMainWithOptions` and `RunMain` functions
+type MainOption func(config *tMainConfig)
+
+// Configuration of `RunMain`
+type tMainConfig struct {
+	output      io.Writer     // Where the error is printed
+	format      OutputFormat  // How the error is printed
+	defaultCode int           // Exit code for the errors which are not mapped
+	exitCodes   ErrorMapper   // Exit codes by error code and by error type
+	cleanups    []func()      // Functions called before exiting
+	signals     []os.Signal   // Signals which interrupt the program
+	timeout     time.Duration // Maximum duration of the shutdown after an interruption, no limit if zero
+	forced      bool          // The exit was forced by a second signal
+
+	crashReports *CrashReportWriter // Writer of crash reports, no report if nil
+}
+
+// WithOutput defines where the error which ends the program is printed, the default is the standard error
+func WithOutput(output io.Writer) MainOption {
+	return func(config *tMainConfig) {
+		config.output = output
+	}
+}
+
+// WithOutputFormat defines how the error which ends the program is printed, the default is `OutputVerbose`
+func WithOutputFormat(format OutputFormat) MainOption {
+	return func(config *tMainConfig) {
+		config.format = format
+	}
+}
+
+// WithDefaultExitCode defines the exit code for the errors which are not mapped, the default is 1
+func WithDefaultExitCode(code int) MainOption {
+	return func(config *tMainConfig) {
+		config.defaultCode = code
+	}
+}
+
+// WithCodeExitCode maps the error code `errorCode` (see `IStandardError.GetCode`) to the exit code `exitCode`.
+// The error codes take precedence over the error types.
+func WithCodeExitCode(errorCode int64, exitCode int) MainOption {
+	return func(config *tMainConfig) {
+		config.exitCodes.MapCode(errorCode, exitCode)
+	}
+}
+
+// WithErrorExitCode maps the errors which have the type of `err` as parent to the exit code `exitCode`.
+// The error `err` is only used for its type, so it can be an uninitialized value like `&NotFoundError{}`.
+// When an error matches several mapped types, the most specific type is used.
+func WithErrorExitCode(err IError, exitCode int) MainOption {
+	return func(config *tMainConfig) {
+		config.exitCodes.MapError(err, exitCode)
+	}
+}
+
+// WithCleanup adds a function called before exiting, even if the program ends with an error or a panic.
+// The cleanup functions are called in the reverse order of their declaration, like deferred calls.
+func WithCleanup(cleanup func()) MainOption {
+	return func(config *tMainConfig) {
+		config.cleanups = append(config.cleanups, cleanup)
+	}
+}
+
+// Make the configuration of `RunMain`
+func makeMainConfig(options []MainOption) *tMainConfig {
+	config := &tMainConfig{
+		output:      os.Stderr,
+		defaultCode: 1,
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+
+	return config
+}
+
+// Call the handler, and get the returned or the raised error
+func (config *tMainConfig) run(handler MainHandler) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			recoveredError, ok := recovered.(error)
+			if ok {
+				err = recoveredError
+			} else {
+				err = fmt.Errorf("error: %s", recovered)
+			}
+		}
+	}()
+
+	return handler()
+}
+
+// Call the cleanup functions, a panic in a cleanup function doesn't prevent the others to be called
+func (config *tMainConfig) cleanup() {
+	for i := len(config.cleanups) - 1; i >= 0; i-- {
+		func() {
+			defer DiscardPanic()
+
+			config.cleanups[i]()
+		}()
+	}
+}
+
+// Print the error which ends the program
+func (config *tMainConfig) report(err IError) {
+	switch config.format {
+	case OutputShort:
+		_, _ = fmt.Fprintln(config.output, oneLine(err))
+
+	case OutputJSON:
+		data, jerr := json.Marshal(err)
+		if jerr != nil {
+			data, _ = json.Marshal(makeJSONError(errors.New(oneLine(err))))
+		}
+
+		_, _ = fmt.Fprintln(config.output, string(data))
+
+	default:
+		_, _ = fmt.Fprintf(config.output, "%+v\n", err)
+	}
+}
+
+// Get the exit code for the error `err`
+func (config *tMainConfig) exitCode(err error) int {
+	if code, found := config.exitCodes.Map(err); found {
+		return code
+	}
+
+	if code, ok := signalExitCode(err); ok {
+		return code
+	}
+
+	return config.defaultCode
+}
+
+// RunMain calls the handler `handler` and returns the exit code of the program: 0 if the handler succeeds, else the
+// exit code mapped to the returned or raised error. The error is printed, then the cleanup functions are called.
+// Unlike `CheckedMain`, the uncatched error handler is not called.
+func RunMain(handler MainHandler, options ...MainOption) int {
+	return RunMainContext(func(context.Context) error { return handler() }, options...)
+}
+
+// RunMainContext is like `RunMain`, but the handler receives a context which is cancelled when the program is
+// interrupted by a signal (see `WithSignals`). After an exit forced by a second signal, the error is only printed:
+// the cleanup functions, the hooks and the crash report are skipped because they may block.
+func RunMainContext(handler ContextMainHandler, options ...MainOption) int {
+	config := makeMainConfig(options)
+
+	defer func() {
+		if !config.forced {
+			config.cleanup()
+		}
+	}()
+
+	err := config.runContext(handler)
+	if err == nil {
+		return 0
+	}
+
+	ierr := toIError(err)
+
+	if config.forced {
+		config.report(ierr)
+
+		return config.exitCode(ierr)
+	}
+
+	uncaughtHooks.fire(ierr)
+
+	if _, interrupted := ierr.(*InterruptedError); !interrupted && (config.crashReports != nil) {
+		config.crashReports.annotate(ierr)
+	}
+
+	config.report(ierr)
+
+	return config.exitCode(ierr)
+}
+
+// CheckedMainWithOptions is like `CheckedMain`, but the exit code and the output are configurable with options.
+// It should be called in the main function body, the process exits only if the handler fails.
+func CheckedMainWithOptions(handler MainHandler, options ...MainOption) {
+	if code := RunMain(handler, options...); code != 0 {
+		osExit(code)
+	}
+}
+
+// CheckedMainContext is like `CheckedMainWithOptions`, but the handler receives a context which is cancelled when the
+// program is interrupted by a signal (see `WithSignals`)
+func CheckedMainContext(handler ContextMainHandler, options ...MainOption) {
+	if code := RunMainContext(handler, options...); code != 0 {
+		osExit(code)
+	}
+}
@@ -0,0 +1,129 @@
+package goerrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRunMainExitCodes(t *testing.T) {
+	options := []MainOption{
+		WithOutput(new(bytes.Buffer)),
+		WithDefaultExitCode(3),
+		WithCodeExitCode(1042, 42),
+		WithErrorExitCode(&NotFoundError{}, 4),
+		WithErrorExitCode(&UserNotFoundError{}, 5),
+	}
+
+	notFound := func() error {
+		err := &NotFoundError{}
+
+		return DecorateError(err.Init(err, "not found", nil, nil, 0))
+	}
+
+	raiseUser := func() error {
+		err := &UserNotFoundError{}
+		_ = err.Init(err, "user", nil, nil, 0)
+
+		err.Raise()
+
+		return nil
+	}
+
+	for name, test := range map[string]struct {
+		handler MainHandler
+		code    int
+	}{
+		"success":        {func() error { return nil }, 0},
+		"default":        {func() error { return errors.New("error") }, 3},
+		"panic":          {func() error { panic("error") }, 3},
+		"code":           {func() error { return MakeErrorWithDatas(1042, nil, "quota") }, 42},
+		"wrapped code":   {func() error { return DecorateError(MakeErrorWithDatas(1042, nil, "quota")) }, 42},
+		"type":           {notFound, 4},
+		"most specific":  {raiseUser, 5},
+		"unmapped code":  {func() error { return MakeErrorWithDatas(7, nil, "error") }, 3},
+		"unmapped error": {func() error { return &TimeoutError{} }, 3},
+	} {
+		if code := RunMain(test.handler, options...); code != test.code {
+			t.Error("Bad exit code for", name, ":", code)
+		}
+	}
+}
+
+func TestRunMainOutput(t *testing.T) {
+	var out bytes.Buffer
+
+	handler := func() error {
+		return MakeError("the %s", "message")
+	}
+
+	_ = RunMain(handler, WithOutput(&out), WithOutputFormat(OutputShort))
+	if out.String() != "github.com/corebreaker/goerrors.StandardError: the message\n" {
+		t.Error("Bad short output:", out.String())
+	}
+
+	out.Reset()
+	_ = RunMain(handler, WithOutput(&out))
+
+	if !strings.HasPrefix(out.String(), "github.com/corebreaker/goerrors.StandardError: the message\n") {
+		t.Error("Bad verbose output:", out.String())
+	}
+
+	out.Reset()
+	_ = RunMain(handler, WithOutput(&out), WithOutputFormat(OutputJSON))
+
+	var decoded map[string]interface{}
+
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatal("The JSON output should be valid:", err, out.String())
+	}
+
+	if decoded["message"] != "the message" {
+		t.Error("Bad JSON output:", out.String())
+	}
+}
+
+func TestRunMainCleanups(t *testing.T) {
+	var calls []string
+
+	code := RunMain(
+		func() error {
+			Raise("error")
+
+			return nil
+		},
+		WithOutput(new(bytes.Buffer)),
+		WithCleanup(func() { calls = append(calls, "first") }),
+		WithCleanup(func() { panic("cleanup") }),
+		WithCleanup(func() { calls = append(calls, "last") }),
+	)
+
+	if code != 1 {
+		t.Error("Bad exit code:", code)
+	}
+
+	if strings.Join(calls, ",") != "last,first" {
+		t.Error("The cleanups should be called in reverse order:", calls)
+	}
+}
+
+func TestCheckedMainWithOptions(t *testing.T) {
+	defer func(old func(int)) { osExit = old }(osExit)
+
+	exitCode := -1
+	osExit = func(code int) { exitCode = code }
+
+	CheckedMainWithOptions(func() error { return nil })
+
+	if exitCode != -1 {
+		t.Error("The process should not exit on success")
+	}
+
+	CheckedMainWithOptions(func() error { return MakeError("error") }, WithOutput(new(bytes.Buffer)))
+
+	if exitCode != 1 {
+		t.Error("Bad exit code:", exitCode)
+	}
+}
@@ -0,0 +1,80 @@
+package goerrors
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Value associated to an error type
+type tTypeMapping struct {
+	name  string // Qualified name of the error type
+	value int    // Mapped value
+}
+
+// ErrorMapper maps errors to values, like HTTP statuses or exit codes.
+// An error is mapped with its code first, then with its type hierarchy.
+// The causes of the error are used too, so a decorated error is mapped like its source.
+// The zero value is an empty mapper, ready to use.
+type ErrorMapper struct {
+	codes map[int64]int  // Values by error code
+	types []tTypeMapping // Values by error type
+}
+
+// NewErrorMapper makes an empty error mapper
+func NewErrorMapper() *ErrorMapper {
+	return &ErrorMapper{}
+}
+
+// MapCode maps the error code `code` (see `IStandardError.GetCode`) to the value `value`.
+// The error codes take precedence over the error types.
+func (m *ErrorMapper) MapCode(code int64, value int) *ErrorMapper {
+	if m.codes == nil {
+		m.codes = make(map[int64]int)
+	}
+
+	m.codes[code] = value
+
+	return m
+}
+
+// MapError maps the errors which have the type of `err` as parent to the value `value`.
+// The error `err` is only used for its type, so it can be an uninitialized value like `&NotFoundError{}`.
+// When an error matches several mapped types, the most specific type is used.
+func (m *ErrorMapper) MapError(err IError, value int) *ErrorMapper {
+	errType := reflect.TypeOf(err)
+	if errType.Kind() == reflect.Ptr {
+		errType = errType.Elem()
+	}
+
+	m.types = append(m.types, tTypeMapping{name: errType.PkgPath() + "." + errType.Name(), value: value})
+
+	return m
+}
+
+// Map gets the value mapped to the error `err`, `found` is false if no mapping matches
+func (m *ErrorMapper) Map(err error) (value int, found bool) {
+	for cause := err; cause != nil; cause = errors.Unwrap(cause) {
+		if serr, ok := cause.(IStandardError); ok && (serr.GetCode() != 0) {
+			if value, found := m.codes[serr.GetCode()]; found {
+				return value, true
+			}
+		}
+	}
+
+	// The most specific type is the deepest type from the root of the hierarchy
+	depth := -1
+
+	for cause := err; cause != nil; cause = errors.Unwrap(cause) {
+		hierarchy := GetHierarchy(cause)
+
+		for i, parent := range hierarchy {
+			for _, entry := range m.types {
+				if (entry.name == parent) && (len(hierarchy)-i > depth) {
+					value, depth = entry.value, len(hierarchy)-i
+				}
+			}
+		}
+	}
+
+	return value, depth >= 0
+}
@@ -0,0 +1,43 @@
+package goerrors
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorMapper(t *testing.T) {
+	mapper := NewErrorMapper().
+		MapError(&GoError{}, 1).
+		MapError(&NotFoundError{}, 2).
+		MapCode(1042, 3)
+
+	newUserNotFound := func() IError {
+		err := &UserNotFoundError{}
+
+		return err.Init(err, "user", nil, nil, 0)
+	}
+
+	for name, test := range map[string]struct {
+		err   error
+		value int
+		found bool
+	}{
+		"specific type": {newUserNotFound(), 2, true},
+		"cause type":    {DecorateError(newUserNotFound()), 2, true},
+		"code":          {MakeErrorWithDatas(1042, nil, ""), 3, true},
+		"cause code":    {DecorateError(MakeErrorWithDatas(1042, nil, "")), 3, true},
+		"unmapped code": {MakeErrorWithDatas(1043, nil, ""), 1, true},
+		"basic error":   {errors.New("error"), 0, false},
+		"nil":           {nil, 0, false},
+	} {
+		if value, found := mapper.Map(test.err); (value != test.value) || (found != test.found) {
+			t.Errorf("Bad mapping for %s: %d, %v", name, value, found)
+		}
+	}
+
+	var empty ErrorMapper
+
+	if _, found := empty.Map(MakeErrorWithDatas(1042, nil, "")); found {
+		t.Error("An empty mapper should not map any error")
+	}
+}
@@ -0,0 +1,231 @@
+package goerrors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// IMultiError Interface for an error which aggregates several errors
+type IMultiError interface {
+	// Base interface
+	IError
+
+	// Add errors in the aggregation, nil errors are ignored
+	Add(errs ...error) IMultiError
+
+	// Get aggregated errors
+	Errors() []error
+
+	// Get the count of aggregated errors
+	Len() int
+
+	// Get this error if there is at least one aggregated error, otherwise it returns nil
+	ErrorOrNil() error
+}
+
+// MultiError Structure type for the multi-error, made by `MakeMultiError` or `CombineErrors`.
+// It can be embedded in a customized error to aggregate several errors, like that:
+//
+//	type ValidationError struct{ goerrors.MultiError }
+//
+//	err := &ValidationError{}
+//	err.Init(err, "validation", nil, nil, 0)
+type MultiError struct {
+	GoError
+
+	mutex  sync.RWMutex // Guard for aggregated errors
+	errors []error      // Aggregated errors
+}
+
+// Add adds errors in the aggregation, nil errors are ignored
+func (me *MultiError) Add(errs ...error) IMultiError {
+	me.mutex.Lock()
+	defer me.mutex.Unlock()
+
+	for _, err := range errs {
+		if err != nil {
+			me.errors = append(me.errors, err)
+		}
+	}
+
+	return me.getMultiReference()
+}
+
+// Errors gets a copy of the list of aggregated errors
+func (me *MultiError) Errors() []error {
+	me.mutex.RLock()
+	defer me.mutex.RUnlock()
+
+	return append([]error(nil), me.errors...)
+}
+
+// Len gets the count of aggregated errors
+func (me *MultiError) Len() int {
+	me.mutex.RLock()
+	defer me.mutex.RUnlock()
+
+	return len(me.errors)
+}
+
+// ErrorOrNil gets this error if there is at least one aggregated error, otherwise it returns nil
+func (me *MultiError) ErrorOrNil() error {
+	if me.Len() == 0 {
+		return nil
+	}
+
+	return me.getMultiReference()
+}
+
+// Get the real reference on multi-error, which is the customized error if multi-error is embedded
+func (me *MultiError) getMultiReference() IMultiError {
+	if ref, ok := me.getReference().(IMultiError); ok {
+		return ref
+	}
+
+	return me
+}
+
+// Error prints the message followed by the numbered list of aggregated errors, each with its own stack trace
+func (me *MultiError) Error() string {
+	var out bytes.Buffer
+
+	errs := me.Errors()
+
+	_, _ = fmt.Fprintf(&out, "%s: ", me.GetName())
+
+	if message := me.GetMessage(); message != "" {
+		_, _ = fmt.Fprintf(&out, "%s ", message)
+	}
+
+	_, _ = fmt.Fprintf(&out, "(%d errors)\n", len(errs))
+
+	for i, err := range errs {
+		writeItem(&out, i, err.Error())
+	}
+
+	writeTrace(&out, me.trace.Frames(), me.raises, me.launch)
+
+	return out.String()
+}
+
+// Format implements the `fmt.Formatter` interface like `GoError.Format`, the aggregated errors are printed too
+func (me *MultiError) Format(s fmt.State, verb rune) {
+	errs := me.Errors()
+
+	switch {
+	case (verb == 'v') && s.Flag('+'):
+		writeReport(s, me.getReference())
+
+		for i, err := range errs {
+			writeItem(s, i, fmt.Sprintf("%+v", err))
+		}
+	case (verb == 'v') && s.Flag('#'):
+		me.GoError.Format(s, verb)
+	case (verb == 'v') || (verb == 's') || (verb == 'q'):
+		items := make([]string, len(errs))
+
+		for i, err := range errs {
+			items[i] = fmt.Sprintf("%v", err)
+		}
+
+		line := fmt.Sprintf("%s: [%s]", oneLine(me.getReference()), strings.Join(items, "; "))
+
+		if verb == 'q' {
+			line = fmt.Sprintf("%q", line)
+		}
+
+		_, _ = io.WriteString(s, line)
+	default:
+		me.GoError.Format(s, verb)
+	}
+}
+
+// Is tests if the error `target` is one of the parents of this error or if it matches one of aggregated errors
+func (me *MultiError) Is(target error) bool {
+	if me.GoError.Is(target) {
+		return true
+	}
+
+	for _, err := range me.Errors() {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds in this error or in the aggregated errors the first value which can be assigned to `target`
+func (me *MultiError) As(target interface{}) bool {
+	if me.GoError.As(target) {
+		return true
+	}
+
+	for _, err := range me.Errors() {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get type of this error followed by types of aggregated errors,
+// so this error is in the hierarchy of all aggregated errors
+func (me *MultiError) getParents() []string {
+	res := me.GoError.getParents()
+
+	for _, err := range me.Errors() {
+		if ierr, ok := err.(IError); ok {
+			res = _concat(res, ierr.getParents())
+		}
+	}
+
+	return res
+}
+
+// Lock the aggregated errors while the error is copied, so they're not modified by `Add` during the copy
+func (me *MultiError) lockState() func() {
+	me.mutex.RLock()
+
+	return me.mutex.RUnlock
+}
+
+// Clone the mutable state shared with the original error after a copy, the mutex is not copied
+func (me *MultiError) cloneState() {
+	me.GoError.cloneState()
+
+	me.errors = append([]error(nil), me.errors...)
+}
+
+// Write an item of the numbered list of aggregated errors
+func writeItem(out io.Writer, index int, text string) {
+	text = strings.TrimSuffix(text, "\n")
+
+	_, _ = fmt.Fprintf(out, "  %d. %s\n", index+1, strings.ReplaceAll(text, "\n", "\n     "))
+}
+
+// MakeMultiError makes an empty multi-error with the message passed as "message" parameter
+func MakeMultiError(message string, args ...interface{}) IMultiError {
+	res := new(MultiError)
+	_ = res.Init(res, fmt.Sprintf(message, args...), nil, nil, 1)
+
+	return res
+}
+
+// CombineErrors makes a multi-error from the errors passed as arguments, nil errors are ignored.
+// It returns nil if there is no error.
+func CombineErrors(errs ...error) IMultiError {
+	res := new(MultiError)
+	_ = res.Init(res, "", nil, nil, 1)
+
+	if res.Add(errs...).Len() == 0 {
+		return nil
+	}
+
+	return res
+}
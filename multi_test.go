@@ -0,0 +1,195 @@
+package goerrors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestMultiError(t *testing.T) {
+	err := MakeMultiError("validation of %s", "user")
+
+	if (err.Len() != 0) || (err.ErrorOrNil() != nil) {
+		t.Error("A new multi-error should be empty")
+	}
+
+	first := errors.New("first")
+	second := MakeError("second")
+
+	if err.Add(first, nil, second) != err {
+		t.Error("Add should return the multi-error itself")
+	}
+
+	errs := err.Errors()
+	if (err.Len() != 2) || (len(errs) != 2) || (errs[0] != first) || (errs[1] != second) {
+		t.Error("Bad aggregated errors:", errs)
+	}
+
+	if err.ErrorOrNil() != err {
+		t.Error("A non-empty multi-error should not be nil")
+	}
+
+	errs[0] = nil
+	if err.Errors()[0] != first {
+		t.Error("The list of aggregated errors should be a copy")
+	}
+
+	if CombineErrors(nil, nil) != nil {
+		t.Error("The combination without error should be nil")
+	}
+
+	if CombineErrors(first, nil).Len() != 1 {
+		t.Error("The combination should aggregate non-nil errors")
+	}
+}
+
+func TestMultiErrorString(t *testing.T) {
+	SetDebug(true)
+	second := MakeError("second\nline")
+	SetDebug(false)
+
+	err := MakeMultiError("validation").Add(errors.New("first"), second)
+
+	lines := strings.Split(err.Error(), "\n")
+	if (lines[0] != "github.com/corebreaker/goerrors.MultiError: validation (2 errors)") ||
+		(lines[1] != "  1. first") ||
+		(lines[2] != "  2. github.com/corebreaker/goerrors.StandardError: second") ||
+		(lines[3] != "     line") ||
+		!strings.Contains(lines[4], "TestMultiErrorString") {
+		t.Error("Bad multi-error printing:", err)
+	}
+
+	if res := fmt.Sprintf("%v", err); res != "github.com/corebreaker/goerrors.MultiError: validation: [first; github.com/corebreaker/goerrors.StandardError: second\nline]" {
+		t.Error("Bad one-line printing:", res)
+	}
+
+	if res := fmt.Sprintf("%q", CombineErrors(errors.New("x"))); res != `"github.com/corebreaker/goerrors.MultiError: [x]"` {
+		t.Error("Bad quoted printing:", res)
+	}
+
+	if res := fmt.Sprintf("%+v", err); !strings.HasPrefix(res, "github.com/corebreaker/goerrors.MultiError: validation\n  1. first\n  2. github.com/corebreaker/goerrors.StandardError: second") {
+		t.Error("Bad report printing:", res)
+	}
+
+	if res := fmt.Sprintf("%#v", err); !strings.HasPrefix(res, "&goerrors.MultiError{") {
+		t.Error("Bad Go-syntax printing:", res)
+	}
+
+	if res := fmt.Sprintf("%d", err); res != "%!d(github.com/corebreaker/goerrors.MultiError: validation)" {
+		t.Error("Bad printing with an unsupported verb:", res)
+	}
+}
+
+func TestMultiErrorHierarchy(t *testing.T) {
+	child := &MyChildError{}
+	_ = child.Init(child, "child", nil, nil, 0)
+
+	_, openErr := os.Open(".a_file_5123351069599224559.txt")
+
+	err := CombineErrors(MakeError("error"), openErr)
+
+	parent := &MyError{}
+	_ = parent.Init(parent, "parent", nil, nil, 0)
+
+	if parent.IsParentOf(err) || errors.Is(err, &MyError{}) {
+		t.Error("The multi-error should not be in the hierarchy without child in the hierarchy")
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Error("The multi-error should match one of its children")
+	}
+
+	err.Add(child)
+
+	if !parent.IsParentOf(err) || !errors.Is(err, &MyError{}) {
+		t.Error("The multi-error should be in the hierarchy of its children")
+	}
+
+	if !MakeMultiError("").IsParentOf(err) {
+		t.Error("The multi-error should be in its own hierarchy")
+	}
+
+	var target *MyError
+
+	if !errors.As(err, &target) || (target != &child.MyError) {
+		t.Error("As should find the parent of a child")
+	}
+
+	var self IMultiError
+
+	if !errors.As(err, &self) || (self != err) {
+		t.Error("As should find the multi-error itself")
+	}
+
+	if errors.As(CombineErrors(openErr), &target) {
+		t.Error("As should not find an error which is not in the children")
+	}
+
+	caught := parent.Try(func(IError) error {
+		err.Raise()
+
+		return nil
+	}, func(err IError) error {
+		return err
+	}, nil)
+
+	if multi, ok := caught.(IMultiError); !ok || (multi.Len() != err.Len()) {
+		t.Error("The multi-error should be caught by the parent of a child:", caught)
+	}
+}
+
+// Customized multi-error
+type tValidationError struct{ MultiError }
+
+func TestMultiErrorEmbedded(t *testing.T) {
+	err := &tValidationError{}
+	_ = err.Init(err, "validation", nil, nil, 0)
+
+	if res := err.Add(errors.New("first")); res != err {
+		t.Error("Add should return the customized error:", res)
+	}
+
+	if (err.ErrorOrNil() != err) || (err.GetName() != "github.com/corebreaker/goerrors.tValidationError") {
+		t.Error("Bad customized multi-error:", err.GetName())
+	}
+
+	if res := fmt.Sprintf("%v", err); res != "github.com/corebreaker/goerrors.tValidationError: validation: [first]" {
+		t.Error("Bad format of a customized multi-error:", res)
+	}
+}
+
+func TestMultiErrorConcurrentCopy(t *testing.T) {
+	err := MakeMultiError("concurrent")
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 1000; i++ {
+			err.Add(fmt.Errorf("error %d", i))
+		}
+	}()
+
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+
+		copied, _ := err.getGoError().copyError()
+
+		if n := copied.(IMultiError).Len(); n > err.Len() {
+			t.Fatal("The copy should not have more errors than the original:", n)
+		}
+	}
+
+	copied, _ := err.getGoError().copyError()
+	copied.(IMultiError).Add(errors.New("copy"))
+
+	if (err.Len() != 1000) || (copied.(IMultiError).Len() != 1001) {
+		t.Error("The copy should not share its errors with the original:", err.Len(), copied.(IMultiError).Len())
+	}
+}
@@ -0,0 +1,388 @@
+// Package report - Asynchronous reporting of errors to a central error tracker, with batching, retries and a bounded
+// queue.
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/corebreaker/goerrors"
+)
+
+// ErrClosed is returned by `Flush` when the reporter is closed
+var ErrClosed = errors.New("reporter closed")
+
+// Report is an error report, as sent to the error tracker
+type Report struct {
+	Time    time.Time       `json:"time"`           // Time of the report
+	Name    string          `json:"name,omitempty"` // Error name, empty for a basic go error
+	Message string          `json:"message"`        // Error on one line
+	Code    int64           `json:"code,omitempty"` // Error code of a standard error
+	Error   json.RawMessage `json:"error"`          // JSON encoding of the error, with its cause chain and stack trace
+}
+
+// MakeReport makes the report of the error `err`
+func MakeReport(err error) Report {
+	res := Report{Time: time.Now(), Message: fmt.Sprintf("%v", err)}
+
+	if ierr, ok := err.(goerrors.IError); ok {
+		res.Name = ierr.GetName()
+	}
+
+	if serr, ok := err.(goerrors.IStandardError); ok {
+		res.Code = serr.GetCode()
+	}
+
+	data, jerr := json.Marshal(err)
+	if (jerr != nil) || (res.Name == "") {
+		data, _ = json.Marshal(struct {
+			Message string `json:"message"`
+		}{err.Error()})
+	}
+
+	res.Error = data
+
+	return res
+}
+
+// Reporter sends errors to an error tracker
+type Reporter interface {
+	// Report sends the error `err`, it doesn't block
+	Report(err error)
+
+	// Flush waits until the reported errors are sent
+	Flush(ctx context.Context) error
+
+	// Close flushes the reported errors and stops the reporter, the errors reported after are dropped
+	Close(ctx context.Context) error
+}
+
+// Sink sends batches of reports, it's used by the asynchronous reporter
+type Sink interface {
+	// Send sends the batch of reports `reports`, a returned error means that the batch can be sent again
+	Send(ctx context.Context, reports []Report) error
+}
+
+// SinkFunc is a function used as a sink
+type SinkFunc func(ctx context.Context, reports []Report) error
+
+// Send calls the function
+func (f SinkFunc) Send(ctx context.Context, reports []Report) error {
+	return f(ctx, reports)
+}
+
+// Options are the options of the asynchronous reporter, the zero value gives the default options
+type Options struct {
+	QueueSize     int                 // Capacity of the queue, reports are dropped when it's full, 100 if zero
+	BatchSize     int                 // Maximum number of reports in a batch, 10 if zero
+	FlushInterval time.Duration       // Maximum delay before sending an incomplete batch, 1s if zero
+	MaxRetries    int                 // Number of retries for a failed batch, 3 if zero, no retry if negative
+	Backoff       time.Duration       // Delay before the first retry, doubled for each retry, 100ms if zero
+	MaxBackoff    time.Duration       // Maximum delay between retries, 10s if zero
+	FlushTimeout  time.Duration       // Maximum duration of the flush done by `Hook` and `Handler`, 5s if zero
+	OnError       func(err error)     // Called when a batch is dropped after the last retry, it may be nil
+	OnDrop        func(report Report) // Called when a report is dropped, it may be nil
+}
+
+// Get the options with default values
+func (o Options) withDefaults() Options {
+	if o.QueueSize <= 0 {
+		o.QueueSize = 100
+	}
+
+	if o.BatchSize <= 0 {
+		o.BatchSize = 10
+	}
+
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = time.Second
+	}
+
+	if o.MaxRetries == 0 {
+		o.MaxRetries = 3
+	}
+
+	if o.Backoff <= 0 {
+		o.Backoff = 100 * time.Millisecond
+	}
+
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 10 * time.Second
+	}
+
+	if o.FlushTimeout <= 0 {
+		o.FlushTimeout = 5 * time.Second
+	}
+
+	return o
+}
+
+// AsyncReporter is a reporter which queues the reports, and sends them by batches to a sink in a goroutine.
+// When the queue is full, the new reports are dropped, and a failed batch is sent again with an exponential backoff.
+type AsyncReporter struct {
+	sink    Sink               // Where the reports are sent
+	options Options            // Options with default values
+	queue   chan Report        // Queued reports
+	flushes chan chan struct{} // Flush requests, the channel is closed when the flush is done
+	done    chan struct{}      // Closed when the sending goroutine ends
+	ctx     context.Context    // Context of the sinks, it's cancelled when the close times out
+	cancel  func()             // Cancellation of the sink context
+	mutex   sync.RWMutex       // Guard for closed
+	closed  bool               // The reporter is closed
+	dropped uint64             // Number of dropped reports
+}
+
+// NewReporter makes an asynchronous reporter which sends the reports to the sink `sink`
+func NewReporter(sink Sink, options Options) *AsyncReporter {
+	options = options.withDefaults()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	res := &AsyncReporter{
+		sink:    sink,
+		options: options,
+		queue:   make(chan Report, options.QueueSize),
+		flushes: make(chan chan struct{}),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	go res.run()
+
+	return res
+}
+
+// Report queues the error `err`, the report is dropped if the queue is full or if the reporter is closed
+func (r *AsyncReporter) Report(err error) {
+	if err == nil {
+		return
+	}
+
+	report := MakeReport(err)
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if r.closed {
+		r.drop(report)
+
+		return
+	}
+
+	select {
+	case r.queue <- report:
+	default:
+		r.drop(report)
+	}
+}
+
+// Hook reports the error `err` and waits until it's sent (see `Options.FlushTimeout`),
+// it can be registered as a goerrors hook:
+//
+//	goerrors.OnUncaught(reporter.Hook)
+//
+// The uncaught errors end the program without calling the deferred functions, so the report is sent before.
+func (r *AsyncReporter) Hook(err goerrors.IError) {
+	r.reportNow(err)
+}
+
+// Handler returns an error handler which reports the error and waits until it's sent (like `Hook`), then calls `next`
+// if it's not nil. It's designed to be used with `goerrors.SetUncatchedErrorHandler`.
+func (r *AsyncReporter) Handler(next goerrors.ErrorHandler) goerrors.ErrorHandler {
+	return func(err goerrors.IError) error {
+		if err != nil {
+			r.reportNow(err)
+		}
+
+		if next == nil {
+			return nil
+		}
+
+		return next(err)
+	}
+}
+
+// Report the error `err` and flush the queue
+func (r *AsyncReporter) reportNow(err error) {
+	r.Report(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.options.FlushTimeout)
+	defer cancel()
+
+	_ = r.Flush(ctx)
+}
+
+// Dropped gets the number of dropped reports, because the queue was full, the reporter was closed, or the sink failed
+func (r *AsyncReporter) Dropped() uint64 {
+	return atomic.LoadUint64(&r.dropped)
+}
+
+// Flush waits until the queued reports are sent
+func (r *AsyncReporter) Flush(ctx context.Context) error {
+	ack := make(chan struct{})
+
+	select {
+	case r.flushes <- ack:
+	case <-r.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close sends the queued reports and stops the reporter.
+// If the context `ctx` ends before, the pending sendings are cancelled and the remaining reports are dropped.
+func (r *AsyncReporter) Close(ctx context.Context) error {
+	r.mutex.Lock()
+
+	if !r.closed {
+		r.closed = true
+
+		close(r.queue)
+	}
+
+	r.mutex.Unlock()
+
+	select {
+	case <-r.done:
+		r.cancel()
+
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+		<-r.done
+
+		return ctx.Err()
+	}
+}
+
+// Count a dropped report
+func (r *AsyncReporter) drop(report Report) {
+	atomic.AddUint64(&r.dropped, 1)
+
+	if r.options.OnDrop != nil {
+		r.options.OnDrop(report)
+	}
+}
+
+// Send the queued reports by batches, until the queue is closed
+func (r *AsyncReporter) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Report, 0, r.options.BatchSize)
+
+	// Add a report in the batch, and send the batch if it's complete
+	add := func(report Report) {
+		batch = append(batch, report)
+
+		if len(batch) >= r.options.BatchSize {
+			r.send(batch)
+
+			batch = make([]Report, 0, r.options.BatchSize)
+		}
+	}
+
+	// Send the incomplete batch
+	flush := func() {
+		if len(batch) != 0 {
+			r.send(batch)
+
+			batch = make([]Report, 0, r.options.BatchSize)
+		}
+	}
+
+	for {
+		select {
+		case report, ok := <-r.queue:
+			if !ok {
+				flush()
+
+				return
+			}
+
+			add(report)
+
+		case <-ticker.C:
+			flush()
+
+		case ack := <-r.flushes:
+			for drained := false; !drained; {
+				select {
+				case report, ok := <-r.queue:
+					if ok {
+						add(report)
+					} else {
+						drained = true
+					}
+
+				default:
+					drained = true
+				}
+			}
+
+			flush()
+			close(ack)
+		}
+	}
+}
+
+// Send a batch to the sink, with retries
+func (r *AsyncReporter) send(batch []Report) {
+	delay := r.options.Backoff
+
+	for attempt := 0; ; attempt++ {
+		err := r.sink.Send(r.ctx, batch)
+		if err == nil {
+			return
+		}
+
+		if (attempt >= r.options.MaxRetries) || (r.ctx.Err() != nil) {
+			r.fail(batch, err)
+
+			return
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-timer.C:
+		case <-r.ctx.Done():
+			timer.Stop()
+			r.fail(batch, r.ctx.Err())
+
+			return
+		}
+
+		delay *= 2
+		if delay > r.options.MaxBackoff {
+			delay = r.options.MaxBackoff
+		}
+	}
+}
+
+// Drop a batch which can't be sent
+func (r *AsyncReporter) fail(batch []Report, err error) {
+	if r.options.OnError != nil {
+		r.options.OnError(err)
+	}
+
+	for _, report := range batch {
+		r.drop(report)
+	}
+}
@@ -0,0 +1,258 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/corebreaker/goerrors"
+)
+
+// Sink which fails a number of times, then sends the reports to a memory sink
+type tFailingSink struct {
+	Memory
+
+	failures int32 // Remaining failures
+	calls    int32 // Number of calls
+}
+
+func (s *tFailingSink) Send(ctx context.Context, reports []Report) error {
+	atomic.AddInt32(&s.calls, 1)
+
+	if atomic.AddInt32(&s.failures, -1) >= 0 {
+		return errors.New("failure")
+	}
+
+	return s.Memory.Send(ctx, reports)
+}
+
+// Sink which blocks until it's released
+type tBlockingSink struct {
+	Memory
+
+	release chan struct{}
+}
+
+func (s *tBlockingSink) Send(ctx context.Context, reports []Report) error {
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return s.Memory.Send(ctx, reports)
+}
+
+func TestMakeReport(t *testing.T) {
+	report := MakeReport(goerrors.MakeErrorWithDatas(1042, nil, "quota exceeded"))
+
+	if (report.Name != "github.com/corebreaker/goerrors.StandardError") || (report.Code != 1042) {
+		t.Error("Bad report:", report)
+	}
+
+	if report.Message != "github.com/corebreaker/goerrors.StandardError: quota exceeded" {
+		t.Error("The message should be on one line:", report.Message)
+	}
+
+	decoded, err := goerrors.UnmarshalError(report.Error)
+	if (err != nil) || (decoded.GetMessage() != "quota exceeded") {
+		t.Error("The error should be encoded with goerrors:", err, decoded)
+	}
+
+	report = MakeReport(errors.New("basic"))
+
+	var basic map[string]string
+
+	if err := json.Unmarshal(report.Error, &basic); (err != nil) || (basic["message"] != "basic") || (report.Name != "") {
+		t.Error("Bad report for a basic error:", string(report.Error), report)
+	}
+}
+
+func TestAsyncReporterBatches(t *testing.T) {
+	var (
+		sink    Memory
+		batches []int
+		mutex   sync.Mutex
+	)
+
+	reporter := NewReporter(SinkFunc(func(ctx context.Context, reports []Report) error {
+		mutex.Lock()
+		batches = append(batches, len(reports))
+		mutex.Unlock()
+
+		return sink.Send(ctx, reports)
+	}), Options{BatchSize: 2, FlushInterval: time.Hour})
+
+	for i := 0; i < 5; i++ {
+		reporter.Report(goerrors.MakeError("error %d", i))
+	}
+
+	reporter.Report(nil)
+
+	if err := reporter.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sink.Reports()) != 5 {
+		t.Error("All reports should be sent:", len(sink.Reports()))
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if (len(batches) != 3) || (batches[0] != 2) || (batches[2] != 1) {
+		t.Error("The reports should be sent by batches:", batches)
+	}
+}
+
+func TestAsyncReporterInterval(t *testing.T) {
+	var sink Memory
+
+	reporter := NewReporter(&sink, Options{FlushInterval: 10 * time.Millisecond})
+	defer func() { _ = reporter.Close(context.Background()) }()
+
+	reporter.Report(errors.New("error"))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for (len(sink.Reports()) == 0) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if len(sink.Reports()) != 1 {
+		t.Error("An incomplete batch should be sent after the flush interval")
+	}
+}
+
+func TestAsyncReporterRetry(t *testing.T) {
+	sink := &tFailingSink{failures: 2}
+	reporter := NewReporter(sink, Options{Backoff: time.Millisecond})
+
+	reporter.Report(errors.New("error"))
+
+	if err := reporter.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if (len(sink.Reports()) != 1) || (sink.calls != 3) || (reporter.Dropped() != 0) {
+		t.Error("A failed batch should be sent again:", len(sink.Reports()), sink.calls, reporter.Dropped())
+	}
+
+	var failure error
+
+	sink = &tFailingSink{failures: 10}
+	reporter = NewReporter(sink, Options{Backoff: time.Millisecond, MaxRetries: 2, OnError: func(err error) {
+		failure = err
+	}})
+
+	reporter.Report(errors.New("error"))
+	_ = reporter.Close(context.Background())
+
+	if (sink.calls != 3) || (reporter.Dropped() != 1) || (failure == nil) {
+		t.Error("A batch should be dropped after the last retry:", sink.calls, reporter.Dropped(), failure)
+	}
+}
+
+func TestAsyncReporterOverflow(t *testing.T) {
+	sink := &tBlockingSink{release: make(chan struct{})}
+
+	var dropped int32
+
+	reporter := NewReporter(sink, Options{QueueSize: 2, BatchSize: 1, OnDrop: func(Report) {
+		atomic.AddInt32(&dropped, 1)
+	}})
+
+	// The first report is blocked in the sink, the 2 next ones fill the queue
+	reporter.Report(errors.New("sent"))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for (len(reporter.queue) != 0) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	for i := 0; i < 5; i++ {
+		reporter.Report(errors.New("queued or dropped"))
+	}
+
+	if (reporter.Dropped() != 3) || (dropped != 3) {
+		t.Error("The reports should be dropped when the queue is full:", reporter.Dropped(), dropped)
+	}
+
+	close(sink.release)
+
+	if err := reporter.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sink.Reports()) != 3 {
+		t.Error("The queued reports should be sent:", len(sink.Reports()))
+	}
+
+	reporter.Report(errors.New("closed"))
+
+	if reporter.Dropped() != 4 {
+		t.Error("The reports should be dropped after the close:", reporter.Dropped())
+	}
+
+	if err := reporter.Flush(context.Background()); err != ErrClosed {
+		t.Error("A flush after the close should fail:", err)
+	}
+}
+
+func TestAsyncReporterCloseTimeout(t *testing.T) {
+	sink := &tBlockingSink{release: make(chan struct{})}
+	reporter := NewReporter(sink, Options{})
+
+	reporter.Report(errors.New("blocked"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := reporter.Close(ctx); err != context.DeadlineExceeded {
+		t.Error("The close should time out:", err)
+	}
+
+	if (len(sink.Reports()) != 0) || (reporter.Dropped() != 1) {
+		t.Error("The pending report should be dropped:", reporter.Dropped())
+	}
+}
+
+func TestAsyncReporterHooks(t *testing.T) {
+	var (
+		sink Memory
+		next goerrors.IError
+	)
+
+	reporter := NewReporter(&sink, Options{FlushInterval: time.Hour})
+	defer func() { _ = reporter.Close(context.Background()) }()
+
+	handler := reporter.Handler(func(err goerrors.IError) error {
+		next = err
+
+		return nil
+	})
+
+	err := goerrors.MakeError("uncaught")
+	_ = handler(err)
+
+	if (next != err) || (len(sink.Reports()) != 1) {
+		t.Error("The error should be sent by the handler before calling the next handler:", next, len(sink.Reports()))
+	}
+
+	defer goerrors.OnUncaught(reporter.Hook)()
+
+	// The program ends without calling the deferred functions, so the report must be sent before the exit
+	goerrors.RunMain(func() error { return errors.New("main") }, goerrors.WithOutput(io.Discard))
+
+	if len(sink.Reports()) != 2 {
+		t.Error("The uncaught error should be sent by the hook before the exit:", len(sink.Reports()))
+	}
+
+	if reporter.Handler(nil)(nil) != nil {
+		t.Error("A handler without next handler should return nil")
+	}
+}
@@ -0,0 +1,160 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// HTTPSink sends the reports as a JSON array in a POST request
+type HTTPSink struct {
+	URL    string       // URL of the error tracker
+	Client *http.Client // HTTP client, the default client if nil
+	Header http.Header  // Additional request headers, for authentication for example
+}
+
+// NewHTTPReporter makes an asynchronous reporter which sends the reports to the URL `url`
+func NewHTTPReporter(url string, options Options) *AsyncReporter {
+	return NewReporter(&HTTPSink{URL: url}, options)
+}
+
+// Send sends the reports, the sending fails if the response status is not a success (2xx)
+func (s *HTTPSink) Send(ctx context.Context, reports []Report) error {
+	body, err := json.Marshal(reports)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for name, values := range s.Header {
+		req.Header[name] = values
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if (resp.StatusCode < 200) || (resp.StatusCode >= 300) {
+		return fmt.Errorf("error tracker response: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// FileSink appends the reports in a file, one JSON object per line
+type FileSink struct {
+	Path string // Path of the file, it's created if it doesn't exist
+
+	mutex sync.Mutex
+}
+
+// NewFileReporter makes an asynchronous reporter which appends the reports in the file `path`
+func NewFileReporter(path string, options Options) *AsyncReporter {
+	return NewReporter(&FileSink{Path: path}, options)
+}
+
+// Send appends the reports in the file
+func (s *FileSink) Send(_ context.Context, reports []Report) error {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+
+	for _, report := range reports {
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	file, err := os.OpenFile(s.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// Memory is an in-memory reporter and sink for tests, the reports are kept in memory
+type Memory struct {
+	mutex   sync.Mutex
+	reports []Report
+}
+
+// Report keeps the report of the error `err`
+func (m *Memory) Report(err error) {
+	if err == nil {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.reports = append(m.reports, MakeReport(err))
+}
+
+// Send keeps the reports
+func (m *Memory) Send(_ context.Context, reports []Report) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.reports = append(m.reports, reports...)
+
+	return nil
+}
+
+// Flush does nothing, the reports are kept immediately
+func (m *Memory) Flush(context.Context) error {
+	return nil
+}
+
+// Close does nothing, the reports are still available
+func (m *Memory) Close(context.Context) error {
+	return nil
+}
+
+// Reports gets the kept reports
+func (m *Memory) Reports() []Report {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return append([]Report(nil), m.reports...)
+}
+
+// Reset removes the kept reports
+func (m *Memory) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.reports = nil
+}
@@ -0,0 +1,174 @@
+package report
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/corebreaker/goerrors"
+)
+
+// Local error tracker which keeps the received reports
+type tCollector struct {
+	mutex    sync.Mutex
+	reports  []Report
+	failures int32 // Number of requests to fail
+}
+
+func (c *tCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if (r.Method != http.MethodPost) || (r.Header.Get("Content-Type") != "application/json") {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	if atomic.AddInt32(&c.failures, -1) >= 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		return
+	}
+
+	var reports []Report
+
+	if err := json.NewDecoder(r.Body).Decode(&reports); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.reports = append(c.reports, reports...)
+}
+
+func TestHTTPSink(t *testing.T) {
+	collector := &tCollector{failures: 1}
+	server := httptest.NewServer(collector)
+
+	defer server.Close()
+
+	reporter := NewReporter(&HTTPSink{
+		URL:    server.URL,
+		Client: server.Client(),
+		Header: http.Header{"Authorization": []string{"Bearer token"}},
+	}, Options{Backoff: time.Millisecond})
+
+	reporter.Report(goerrors.MakeErrorWithDatas(1042, nil, "quota exceeded"))
+	reporter.Report(errors.New("basic"))
+
+	if err := reporter.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if reporter.Dropped() != 0 {
+		t.Error("The reports should be sent after a retry:", reporter.Dropped())
+	}
+
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	if (len(collector.reports) != 2) || (collector.reports[0].Code != 1042) || (collector.reports[1].Message != "basic") {
+		t.Error("Bad collected reports:", collector.reports)
+	}
+}
+
+func TestHTTPSinkFailure(t *testing.T) {
+	server := httptest.NewServer(&tCollector{})
+
+	defer server.Close()
+
+	reporter := NewHTTPReporter(server.URL, Options{MaxRetries: -1})
+
+	reporter.Report(errors.New("unauthorized"))
+
+	if err := reporter.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if reporter.Dropped() != 1 {
+		t.Error("A rejected report should be dropped:", reporter.Dropped())
+	}
+
+	if (&HTTPSink{URL: "://bad"}).Send(context.Background(), nil) == nil {
+		t.Error("A bad URL should fail")
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+	reporter := NewFileReporter(path, Options{BatchSize: 2})
+
+	for i := 0; i < 3; i++ {
+		reporter.Report(goerrors.MakeError("error %d", i))
+	}
+
+	if err := reporter.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	var messages []string
+
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		var report Report
+
+		if err := json.Unmarshal(scanner.Bytes(), &report); err != nil {
+			t.Fatal("Each line should be a report:", err)
+		}
+
+		messages = append(messages, report.Message)
+	}
+
+	if (len(messages) != 3) || (messages[2] != "github.com/corebreaker/goerrors.StandardError: error 2") {
+		t.Error("Bad reports in file:", messages)
+	}
+
+	if (&FileSink{Path: filepath.Join(path, "bad")}).Send(context.Background(), nil) == nil {
+		t.Error("A bad path should fail")
+	}
+}
+
+func TestMemory(t *testing.T) {
+	var reporter Reporter = new(Memory)
+
+	reporter.Report(errors.New("error"))
+	reporter.Report(nil)
+
+	if (reporter.Flush(context.Background()) != nil) || (reporter.Close(context.Background()) != nil) {
+		t.Error("The memory reporter should not fail")
+	}
+
+	memory := reporter.(*Memory)
+
+	if reports := memory.Reports(); (len(reports) != 1) || (reports[0].Message != "error") {
+		t.Error("Bad kept reports:", reports)
+	}
+
+	memory.Reset()
+
+	if len(memory.Reports()) != 0 {
+		t.Error("The reports should be removed")
+	}
+}
@@ -0,0 +1,152 @@
+package goerrors
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+var (
+	// Functions used to receive the signals, they're replaced in tests
+	signalNotify = signal.Notify
+	signalStop   = signal.Stop
+)
+
+// InterruptedError is the error returned by `RunMainContext` when the program is interrupted by a signal.
+// The exit code of the program is 128 plus the signal number, unless the error is mapped to another exit code.
+type InterruptedError struct {
+	StandardError
+
+	Signal os.Signal // Received signal
+}
+
+// ShutdownTimeoutError is the error returned by `RunMainContext` when the handler doesn't end in time after an
+// interruption (see `WithShutdownTimeout`). Its source is the `InterruptedError`.
+type ShutdownTimeoutError struct {
+	StandardError
+
+	Stacks string // Stacks of all goroutines when the timeout expired
+}
+
+// WithSignals defines the signals which interrupt the program, by default SIGINT and SIGTERM.
+// When a signal is received, the context passed to the handler is cancelled, and the program ends with an
+// `InterruptedError` when the handler returns. A second signal forces the exit without waiting for the handler, and
+// without calling the cleanup functions, the hooks and the crash report.
+func WithSignals(signals ...os.Signal) MainOption {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	return func(config *tMainConfig) {
+		config.signals = signals
+	}
+}
+
+// WithShutdownTimeout defines the maximum duration for the handler to end after an interruption.
+// When the timeout expires, the program ends with a `ShutdownTimeoutError` without waiting for the handler.
+func WithShutdownTimeout(timeout time.Duration) MainOption {
+	return func(config *tMainConfig) {
+		config.timeout = timeout
+	}
+}
+
+// Make an interruption error, the error `err` returned by the handler is the source if it's not the cancellation
+func makeInterruptedError(sig os.Signal, err error) *InterruptedError {
+	if errors.Is(err, context.Canceled) {
+		err = nil
+	}
+
+	res := &InterruptedError{Signal: sig}
+	_ = res.Init(res, "Interrupted by the signal "+sig.String(), nil, err, 1)
+
+	return res
+}
+
+// Make a shutdown timeout error with the stacks of all goroutines
+func makeShutdownTimeoutError(timeout time.Duration, interruption error) *ShutdownTimeoutError {
+	res := &ShutdownTimeoutError{Stacks: goroutineStacks()}
+	_ = res.Init(res, "The shutdown did not end after "+timeout.String(), nil, interruption, 1)
+	_ = res.AddInfo("Running goroutines:\n%s", res.Stacks)
+
+	return res
+}
+
+// Get the exit code of an interruption in the error chain of `err`
+func signalExitCode(err error) (int, bool) {
+	var interruption *InterruptedError
+
+	if !errors.As(err, &interruption) {
+		return 0, false
+	}
+
+	sig, ok := interruption.Signal.(syscall.Signal)
+	if !ok {
+		return 0, false
+	}
+
+	return 128 + int(sig), true
+}
+
+// Call the handler with a context cancelled by the configured signals, and get the returned or the raised error
+func (config *tMainConfig) runContext(handler ContextMainHandler) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	call := func() error {
+		return handler(ctx)
+	}
+
+	if len(config.signals) == 0 {
+		return config.run(call)
+	}
+
+	signals := make(chan os.Signal, 2)
+
+	signalNotify(signals, config.signals...)
+	defer signalStop(signals)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- config.run(call)
+	}()
+
+	var sig os.Signal
+
+	select {
+	case err := <-done:
+		return err
+
+	case sig = <-signals:
+	}
+
+	cancel()
+
+	var timeout <-chan time.Time
+
+	if config.timeout > 0 {
+		timer := time.NewTimer(config.timeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+	case err := <-done:
+		return makeInterruptedError(sig, err)
+
+	case second := <-signals:
+		config.forced = true
+
+		res := makeInterruptedError(second, nil)
+		_ = res.AddInfo("Forced exit, the first signal was %s", sig)
+
+		return res
+
+	case <-timeout:
+		return makeShutdownTimeoutError(config.timeout, makeInterruptedError(sig, nil))
+	}
+}
@@ -0,0 +1,184 @@
+package goerrors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// Replace the signal notification, the returned channel receives the channel passed to `signalNotify`
+func fakeSignals(t *testing.T) chan chan<- os.Signal {
+	oldNotify, oldStop := signalNotify, signalStop
+	t.Cleanup(func() { signalNotify, signalStop = oldNotify, oldStop })
+
+	notified := make(chan chan<- os.Signal, 1)
+
+	signalNotify = func(c chan<- os.Signal, sig ...os.Signal) { notified <- c }
+	signalStop = func(c chan<- os.Signal) {}
+
+	return notified
+}
+
+func TestRunMainContextSignal(t *testing.T) {
+	notified := fakeSignals(t)
+
+	go func() { (<-notified) <- syscall.SIGTERM }()
+
+	code := RunMainContext(
+		func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		},
+		WithOutput(new(bytes.Buffer)),
+		WithSignals(),
+		WithCleanup(func() {}),
+	)
+
+	if code != 128+int(syscall.SIGTERM) {
+		t.Error("Bad exit code:", code)
+	}
+
+	if makeInterruptedError(syscall.SIGINT, context.Canceled).GetSource() != nil {
+		t.Error("The cancellation should not be the source of the interruption")
+	}
+}
+
+func TestRunMainContextMapping(t *testing.T) {
+	notified := fakeSignals(t)
+
+	go func() { (<-notified) <- os.Interrupt }()
+
+	var out bytes.Buffer
+
+	code := RunMainContext(
+		func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return errors.New("stopped")
+		},
+		WithOutput(&out),
+		WithOutputFormat(OutputShort),
+		WithSignals(os.Interrupt),
+		WithErrorExitCode(&InterruptedError{}, 9),
+	)
+
+	if code != 9 {
+		t.Error("Bad exit code:", code)
+	}
+
+	if !strings.Contains(out.String(), "InterruptedError: Interrupted by the signal interrupt") {
+		t.Error("Bad output:", out.String())
+	}
+}
+
+func TestRunMainContextForcedExit(t *testing.T) {
+	notified := fakeSignals(t)
+	release := make(chan struct{})
+
+	defer close(release)
+
+	go func() {
+		c := <-notified
+		c <- syscall.SIGTERM
+		c <- syscall.SIGINT
+	}()
+
+	var (
+		out     bytes.Buffer
+		skipped = true
+		dir     = t.TempDir()
+	)
+
+	defer OnUncaught(func(IError) { skipped = false })()
+
+	code := RunMainContext(
+		func(ctx context.Context) error {
+			<-release
+
+			return nil
+		},
+		WithOutput(&out),
+		WithOutputFormat(OutputShort),
+		WithSignals(),
+		WithCleanup(func() { skipped = false }),
+		WithCrashReport(&CrashReportWriter{Dir: dir}),
+	)
+
+	if code != 128+int(syscall.SIGINT) {
+		t.Error("Bad exit code:", code)
+	}
+
+	if !strings.Contains(out.String(), "Interrupted by the signal interrupt") {
+		t.Error("The error should be printed:", out.String())
+	}
+
+	if reports, _ := os.ReadDir(dir); !skipped || (len(reports) != 0) {
+		t.Error("The cleanups, the hooks and the crash report should be skipped after a forced exit")
+	}
+}
+
+func TestRunMainContextShutdownTimeout(t *testing.T) {
+	notified := fakeSignals(t)
+	release := make(chan struct{})
+
+	defer close(release)
+
+	go func() { (<-notified) <- syscall.SIGTERM }()
+
+	var out bytes.Buffer
+
+	code := RunMainContext(
+		func(ctx context.Context) error {
+			<-release
+
+			return nil
+		},
+		WithOutput(&out),
+		WithSignals(),
+		WithShutdownTimeout(10*time.Millisecond),
+		WithErrorExitCode(&ShutdownTimeoutError{}, 70),
+	)
+
+	if code != 70 {
+		t.Error("Bad exit code:", code)
+	}
+
+	if !strings.Contains(out.String(), "TestRunMainContextShutdownTimeout") {
+		t.Error("The stacks of the running goroutines should be printed:", out.String())
+	}
+}
+
+func TestRunMainContextWithoutSignal(t *testing.T) {
+	fakeSignals(t)
+
+	code := RunMainContext(func(ctx context.Context) error {
+		if ctx.Err() != nil {
+			t.Error("The context should not be cancelled")
+		}
+
+		return nil
+	})
+
+	if code != 0 {
+		t.Error("Bad exit code:", code)
+	}
+}
+
+func TestCheckedMainContext(t *testing.T) {
+	defer func(old func(int)) { osExit = old }(osExit)
+
+	exitCode := -1
+	osExit = func(code int) { exitCode = code }
+
+	CheckedMainContext(func(ctx context.Context) error { return MakeError("error") }, WithOutput(new(bytes.Buffer)))
+
+	if exitCode != 1 {
+		t.Error("Bad exit code:", exitCode)
+	}
+}
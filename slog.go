@@ -0,0 +1,170 @@
+package goerrors
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implements the `slog.LogValuer` interface,
+// the error is logged as a group with its name, message, code, data, cause, aggregated errors and stack frames.
+func (goErr *GoError) LogValue() slog.Value {
+	return slog.GroupValue(logAttrs(goErr.getReference())...)
+}
+
+// Get the attributes for logging the error `err`
+func logAttrs(err IError) []slog.Attr {
+	res := []slog.Attr{
+		slog.String("name", err.GetName()),
+		slog.String("message", err.GetMessage()),
+	}
+
+	serr, isStandard := err.(IStandardError)
+	if isStandard && (serr.GetCode() != 0) {
+		res = append(res, slog.Int64("code", serr.GetCode()))
+	}
+
+	if data := err.GetData(); data != nil {
+		res = append(res, slog.Any("data", data))
+	}
+
+	if fields := err.Fields(); len(fields) > 0 {
+		attrs := make([]any, 0, len(fields))
+
+		for _, key := range sortedKeys(fields) {
+			attrs = append(attrs, slog.Any(key, fields[key]))
+		}
+
+		res = append(res, slog.Group("fields", attrs...))
+	}
+
+	if source := err.GetSource(); source != nil {
+		if ierr, ok := source.(IError); ok {
+			res = append(res, slog.Attr{Key: "cause", Value: slog.GroupValue(logAttrs(ierr)...)})
+		} else {
+			res = append(res, slog.String("cause", source.Error()))
+		}
+	}
+
+	if merr, ok := err.(IMultiError); ok && (merr.Len() > 0) {
+		errs := merr.Errors()
+		attrs := make([]any, len(errs))
+
+		// The aggregated errors are numbered from 1, like in the error message
+		for i, item := range errs {
+			key := strconv.Itoa(i + 1)
+
+			if ierr, ok := item.(IError); ok {
+				attrs[i] = slog.Attr{Key: key, Value: slog.GroupValue(logAttrs(ierr)...)}
+			} else {
+				attrs[i] = slog.String(key, item.Error())
+			}
+		}
+
+		res = append(res, slog.Group("errors", attrs...))
+	}
+
+	if isStandard {
+		if infos := serr.Infos(); len(infos) > 0 {
+			res = append(res, slog.Any("infos", infos))
+		}
+	}
+
+	if frames := err.StackTrace(); len(frames) > 0 {
+		res = append(res, slog.Any("frames", frames))
+	}
+
+	if raises := err.RaiseHistory(); len(raises) > 0 {
+		res = append(res, slog.Any("raises", raises))
+	}
+
+	if launch := err.LauncherStack(); len(launch) > 0 {
+		res = append(res, slog.Any("launch", launch))
+	}
+
+	return res
+}
+
+// Handler for `slog` which expands the errors
+type tSlogHandler struct {
+	handler slog.Handler // Wrapped handler
+}
+
+// NewSlogHandler wraps the `slog` handler `handler`, the attributes which are IError are expanded into groups with,
+// in addition to the fields given by `LogValue`, the type hierarchy and the stack trace of the whole cause chain.
+func NewSlogHandler(handler slog.Handler) slog.Handler {
+	return &tSlogHandler{handler: handler}
+}
+
+// Enabled implements the `slog.Handler` interface
+func (sh *tSlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return sh.handler.Enabled(ctx, level)
+}
+
+// Handle implements the `slog.Handler` interface
+func (sh *tSlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	res := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		res.AddAttrs(expandLogAttr(attr))
+
+		return true
+	})
+
+	return sh.handler.Handle(ctx, res)
+}
+
+// WithAttrs implements the `slog.Handler` interface
+func (sh *tSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+
+	for i, attr := range attrs {
+		expanded[i] = expandLogAttr(attr)
+	}
+
+	return &tSlogHandler{handler: sh.handler.WithAttrs(expanded)}
+}
+
+// WithGroup implements the `slog.Handler` interface
+func (sh *tSlogHandler) WithGroup(name string) slog.Handler {
+	return &tSlogHandler{handler: sh.handler.WithGroup(name)}
+}
+
+// Expand the attribute if it's an IError, the attributes in groups are expanded too
+func expandLogAttr(attr slog.Attr) slog.Attr {
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		group := attr.Value.Group()
+		expanded := make([]slog.Attr, len(group))
+
+		for i, sub := range group {
+			expanded[i] = expandLogAttr(sub)
+		}
+
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(expanded...)}
+	case slog.KindAny, slog.KindLogValuer:
+		err, ok := attr.Value.Any().(IError)
+		if !ok {
+			return attr
+		}
+
+		attrs := logAttrs(err)
+		attrs = append(attrs, slog.Any("hierarchy", err.getParents()))
+
+		var trace []string
+
+		for cause, ok := IError(err), true; ok; cause, ok = cause.GetSource().(IError) {
+			for _, frame := range cause.StackTrace() {
+				trace = append(trace, frame.String())
+			}
+		}
+
+		if len(trace) > 0 {
+			attrs = append(attrs, slog.Any("trace", trace))
+		}
+
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(attrs...)}
+	default:
+		return attr
+	}
+}
@@ -0,0 +1,147 @@
+package goerrors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLogValue(t *testing.T) {
+	cause := &MyError{}
+	_ = cause.Init(cause, "cause", nil, errors.New("origin"), 0)
+
+	var out bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+	logger.Error("failure", "error", DecorateErrorWithDatas(cause, 12, "data", "message"))
+
+	if strings.Count(out.String(), "\n") != 1 {
+		t.Error("The error should be logged on one line:", out.String())
+	}
+
+	var entry map[string]interface{}
+
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"name":    "github.com/corebreaker/goerrors.StandardError",
+		"message": "message",
+		"code":    12.0,
+		"data":    "data",
+		"cause": map[string]interface{}{
+			"name":    "github.com/corebreaker/goerrors.MyError",
+			"message": "cause",
+			"cause":   "origin",
+		},
+	}
+
+	if !reflect.DeepEqual(entry["error"], expected) {
+		t.Error("Bad logged error:", entry["error"])
+	}
+}
+
+func TestLogValueMultiError(t *testing.T) {
+	var out bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+	logger.Error("failure", "error", CombineErrors(errors.New("a"), MakeError("b")))
+
+	var entry map[string]interface{}
+
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"1": "a",
+		"2": map[string]interface{}{
+			"name":    "github.com/corebreaker/goerrors.StandardError",
+			"message": "b",
+		},
+	}
+
+	if errs := entry["error"].(map[string]interface{})["errors"]; !reflect.DeepEqual(errs, expected) {
+		t.Error("The aggregated errors should be logged:", out.String())
+	}
+}
+
+func TestLogValueFrames(t *testing.T) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	value := MakeError("error").(slog.LogValuer).LogValue()
+
+	for _, attr := range value.Group() {
+		if attr.Key == "frames" {
+			if frames := attr.Value.Any().([]Frame); frames[0].Function != "TestLogValueFrames" {
+				t.Error("Bad logged frames:", frames)
+			}
+
+			return
+		}
+	}
+
+	t.Error("The frames should be logged")
+}
+
+func TestSlogHandler(t *testing.T) {
+	SetDebug(true)
+	cause := MakeError("cause")
+	SetDebug(false)
+
+	var out bytes.Buffer
+
+	handler := NewSlogHandler(slog.NewJSONHandler(&out, nil))
+	if !handler.Enabled(context.Background(), slog.LevelInfo) || handler.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("The level should be checked by the wrapped handler")
+	}
+
+	logger := slog.New(handler).With("base", DecorateError(cause)).WithGroup("group")
+	logger.Info("failure", "error", cause, "other", 1, slog.Group("sub", "error", cause))
+
+	var entry map[string]interface{}
+
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatal(err, out.String())
+	}
+
+	check := func(title string, value interface{}) {
+		logged, ok := value.(map[string]interface{})
+		if !ok {
+			t.Error("The error should be expanded in", title, value)
+
+			return
+		}
+
+		hierarchy := []interface{}{
+			"github.com/corebreaker/goerrors.StandardError",
+			"github.com/corebreaker/goerrors.GoError",
+		}
+
+		if !reflect.DeepEqual(logged["hierarchy"], hierarchy) {
+			t.Error("Bad hierarchy in", title, logged["hierarchy"])
+		}
+
+		trace, ok := logged["trace"].([]interface{})
+		if !ok || !strings.Contains(trace[0].(string), "TestSlogHandler") {
+			t.Error("Bad trace in", title, logged["trace"])
+		}
+	}
+
+	group := entry["group"].(map[string]interface{})
+
+	check("attributes of the logger", entry["base"])
+	check("attributes of the record", group["error"])
+	check("groups", group["sub"].(map[string]interface{})["error"])
+
+	if group["other"] != 1.0 {
+		t.Error("The other attributes should be kept:", group["other"])
+	}
+}
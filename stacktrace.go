@@ -4,34 +4,133 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"sync"
 )
 
 // STACKTRACE_MAXLEN this version of stack trace asks to have a limit which arbitrary set.
 const STACKTRACE_MAXLEN = 65536
 
-// Construct formated stack trace.
-func getTrace(start uint) []string {
-	// The resulting stack trace.
-	var trace []string
+// Initial size of the program counter buffer used to capture a stack trace, the buffer grows up to STACKTRACE_MAXLEN.
+const stacktraceInitialLen = 32
 
+var (
+	// Symbolization cache, it maps a program counter to a `tCachedFrame`
+	frameCache sync.Map
+)
+
+// Frame is an entry of a stack trace
+type Frame struct {
+	Function string  `json:"function"`          // Function name, without the package path (ex: `(*GoError).Init`)
+	Package  string  `json:"package,omitempty"` // Package path of the function (ex: `github.com/corebreaker/goerrors`)
+	File     string  `json:"file"`              // Source file path
+	Line     int     `json:"line"`              // Line number in the source file
+	PC       uintptr `json:"pc,omitempty"`      // Program counter
+}
+
+// String formats the frame like `package.function (file:line)`
+func (f Frame) String() string {
+	return fmt.Sprintf("%s (%s:%d)", f.FullName(), f.File, f.Line)
+}
+
+// FullName gets the function name qualified with its package path
+func (f Frame) FullName() string {
+	if f.Package == "" {
+		return f.Function
+	}
+
+	return f.Package + "." + f.Function
+}
+
+// Make a frame from a runtime frame.
+func makeFrame(frame runtime.Frame) Frame {
+	pkg, function := _splitFunctionName(frame.Function)
+
+	return Frame{
+		Function: function,
+		Package:  pkg,
+		File:     frame.File,
+		Line:     frame.Line,
+		PC:       frame.PC,
+	}
+}
+
+// Entry of the symbolization cache
+type tCachedFrame struct {
+	frames []Frame // Frames for a program counter, empty if the program counter is in the `runtime` package
+}
+
+// Stack trace captured as program counters, the frames are only resolved when they are read.
+type tStack struct {
+	pcs    []uintptr // Program counters
+	once   sync.Once // Guard for the frame resolution
+	frames []Frame   // Resolved frames
+}
+
+// Captures the stack trace of the caller, it only records the program counters.
+func captureStack(start uint) *tStack {
 	// The caller list.
-	callers := make([]uintptr, STACKTRACE_MAXLEN)
+	callers := make([]uintptr, stacktraceInitialLen)
+
+	for {
+		n := runtime.Callers(int(start+2), callers)
+
+		// If the buffer was large enough (or can't grow anymore), so the stack is fully captured.
+		if (n < len(callers)) || (len(callers) >= STACKTRACE_MAXLEN) {
+			return &tStack{pcs: callers[:n]}
+		}
+
+		size := 2 * len(callers)
+		if size > STACKTRACE_MAXLEN {
+			size = STACKTRACE_MAXLEN
+		}
 
-	// Gets the caller list, and returns an empty stack trace if there is no caller.
-	n := runtime.Callers(int(start+2), callers)
-	if n == 0 {
-		return trace
+		callers = make([]uintptr, size)
+	}
+}
+
+// Make a stack trace from frames which are already resolved, it returns nil if there is no frame.
+func makeResolvedStack(frames []Frame) *tStack {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	res := &tStack{frames: frames}
+	res.once.Do(func() {})
+
+	return res
+}
+
+// Frames gets the frames of the stack trace, they are resolved on the first call.
+func (s *tStack) Frames() []Frame {
+	if s == nil {
+		return nil
+	}
+
+	s.once.Do(func() {
+		for _, pc := range s.pcs {
+			s.frames = append(s.frames, resolveFrames(pc)...)
+		}
+	})
+
+	return s.frames
+}
+
+// Resolve frames for one program counter, the result is kept in the symbolization cache.
+func resolveFrames(pc uintptr) []Frame {
+	if cached, ok := frameCache.Load(pc); ok {
+		return cached.(*tCachedFrame).frames
 	}
 
-	// Get frames from callers.
-	frames := runtime.CallersFrames(callers[:n])
+	var res []Frame
+
+	// Get frames from the caller.
+	frames := runtime.CallersFrames([]uintptr{pc})
 
-	// Populates the stack trace.
 	for hasMore := true; hasMore; {
 		// A stack frame.
 		var frame runtime.Frame
 
-		// Gets the next frame/
+		// Gets the next frame.
 		frame, hasMore = frames.Next()
 
 		// If the file is from `runtime` package, so go to the next frame.
@@ -39,10 +138,24 @@ func getTrace(start uint) []string {
 			continue
 		}
 
-		// Adds the stack trace entry.
-		trace = append(trace, fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+		res = append(res, makeFrame(frame))
 	}
 
-	// Returns stack trace.
-	return trace
+	frameCache.Store(pc, &tCachedFrame{frames: res})
+
+	return res
+}
+
+// Get the stacks of all goroutines, as formatted by `runtime.Stack`
+func goroutineStacks() string {
+	buf := make([]byte, 64*1024)
+
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+
+		buf = make([]byte, len(buf)*2)
+	}
 }
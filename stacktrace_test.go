@@ -1,12 +1,159 @@
 package goerrors
 
 import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 )
 
 func TestNoTrace(t *testing.T) {
-	trace := getTrace(0)
-	if len(getTrace(uint(len(trace))+1)) > 0 {
+	trace := captureStack(0)
+	if len(captureStack(uint(len(trace.pcs))+1).Frames()) > 0 {
 		t.Fail()
 	}
+
+	var noTrace *tStack
+
+	if noTrace.Frames() != nil {
+		t.Error("A nil stack trace should not have frames")
+	}
+}
+
+func TestTraceFrames(t *testing.T) {
+	trace := captureStack(0).Frames()
+	if len(trace) == 0 {
+		t.Fatal("The stack trace should not be empty")
+	}
+
+	frame := trace[0]
+	if (frame.Package != "github.com/corebreaker/goerrors") || (frame.Function != "TestTraceFrames") {
+		t.Error("Bad function in the first frame:", frame.Package, frame.Function)
+	}
+
+	if !strings.HasSuffix(frame.File, "stacktrace_test.go") || (frame.Line == 0) || (frame.PC == 0) {
+		t.Error("Bad location in the first frame:", frame.File, frame.Line, frame.PC)
+	}
+
+	if frame.String() != "github.com/corebreaker/goerrors.TestTraceFrames ("+frame.File+":"+strconv.Itoa(frame.Line)+")" {
+		t.Error("Bad frame formatting:", frame.String())
+	}
+}
+
+func TestDeepTrace(t *testing.T) {
+	var recurse func(depth int) *tStack
+
+	recurse = func(depth int) *tStack {
+		if depth == 0 {
+			return captureStack(0)
+		}
+
+		return recurse(depth - 1)
+	}
+
+	if trace := recurse(3 * stacktraceInitialLen); len(trace.pcs) <= 3*stacktraceInitialLen {
+		t.Error("The captured stack trace should grow beyond its initial length:", len(trace.pcs))
+	}
+}
+
+func TestTraceCache(t *testing.T) {
+	trace := captureStack(0)
+	frames := trace.Frames()
+
+	if cached, ok := frameCache.Load(trace.pcs[0]); !ok || (cached.(*tCachedFrame).frames[0] != frames[0]) {
+		t.Error("The resolved frames should be cached")
+	}
+
+	if &trace.Frames()[0] != &frames[0] {
+		t.Error("The frames should be resolved only once")
+	}
+}
+
+func TestFrameFullName(t *testing.T) {
+	if (Frame{Function: "main"}).FullName() != "main" {
+		t.Error("A frame without package should have the function name as full name")
+	}
+
+	if (Frame{Package: "main", Function: "main"}).FullName() != "main.main" {
+		t.Error("A frame with package should have a qualified full name")
+	}
+}
+
+func TestErrorStackTrace(t *testing.T) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	trace := MakeError("error").StackTrace()
+	if len(trace) == 0 {
+		t.Fatal("The stack trace should not be empty in debug mode")
+	}
+
+	if trace[0].Function != "TestErrorStackTrace" {
+		t.Error("The stack trace should start at the error creation:", trace[0])
+	}
+}
+
+// The former implementation which formats the stack trace when the error is created, kept for benchmark comparison.
+func eagerTrace(start uint) []string {
+	var trace []string
+
+	callers := make([]uintptr, STACKTRACE_MAXLEN)
+
+	n := runtime.Callers(int(start+2), callers)
+	if n == 0 {
+		return trace
+	}
+
+	frames := runtime.CallersFrames(callers[:n])
+
+	for hasMore := true; hasMore; {
+		var frame runtime.Frame
+
+		frame, hasMore = frames.Next()
+		if strings.Contains(frame.File, "runtime/") {
+			continue
+		}
+
+		trace = append(trace, fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+	}
+
+	return trace
+}
+
+func BenchmarkEagerTrace(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = eagerTrace(0)
+	}
+}
+
+func BenchmarkCaptureStack(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = captureStack(0)
+	}
+}
+
+func BenchmarkCaptureStackAndFrames(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = captureStack(0).Frames()
+	}
+}
+
+func BenchmarkDecorateErrorDebug(b *testing.B) {
+	SetDebug(true)
+	defer SetDebug(false)
+
+	err := fmt.Errorf("error")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = DecorateError(err)
+	}
 }
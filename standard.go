@@ -1,7 +1,7 @@
 package goerrors
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 )
 
@@ -16,34 +16,105 @@ type IStandardError interface {
 
 	// Get error code
 	GetCode() int64
+
+	// Get the definition of the error code, or nil if the code is not registered
+	GetCodeDefinition() *CodeDefinition
+
+	// Get the informations added on that error
+	Infos() []Info
+
+	// Add a key/value field on that error
+	WithField(key string, value interface{}) IStandardError
+
+	// Add key/value fields on that error
+	WithFields(fields map[string]interface{}) IStandardError
+
+	// Add an information on that error
+	addInfo(info Info)
 }
 
-// Internal structure type for the standard error
-type tStandardError struct {
+// StandardError Structure type for the standard error.
+// It can be embedded in a customized error to give it an error code and additionnal informations, like that:
+//
+//	type MyError struct{ goerrors.StandardError }
+//
+//	err := &MyError{}
+//	err.InitWithCode(err, 1042, "message", nil, nil, 0)
+type StandardError struct {
 	GoError
 
-	code  int64        // Error code
-	infos bytes.Buffer // Additionnal informations
+	code  int64  // Error code
+	infos []Info // Additionnal informations
 }
 
-// GetName gets standard error name
-func (se *tStandardError) GetName() string {
-	return "StandardError"
+// InitWithCode is like `Init` for initializing customized error, with the error code `code`
+func (se *StandardError) InitWithCode(
+	value interface{},
+	code int64,
+	message string,
+	data interface{},
+	source error,
+	pruneLevels uint,
+) IStandardError {
+	res := se.init(GetDebug(), value, message, data, source, pruneLevels+1)
+	if res.getGoError() != &se.GoError {
+		createHooks.fire(res)
+
+		return res.(IStandardError)
+	}
+
+	se.code = code
+	createHooks.fire(res)
+
+	return se.getStandardReference()
 }
 
 // AddInfo adds informations in standard error
-func (se *tStandardError) AddInfo(info string, args ...interface{}) IStandardError {
-	// Just prints into internal buffer the informations passed as parameters
-	_, _ = fmt.Fprintln(&se.infos, fmt.Sprintf(info, args...))
+func (se *StandardError) AddInfo(info string, args ...interface{}) IStandardError {
+	se.addInfo(makeInfo(InfoNote, fmt.Sprintf(info, args...), 1))
+
+	return se.getStandardReference()
+}
+
+// Infos gets the informations added on standard error
+func (se *StandardError) Infos() []Info {
+	return append([]Info(nil), se.infos...)
+}
+
+// Add an information on standard error
+func (se *StandardError) addInfo(info Info) {
+	se.infos = append(se.infos, info)
+}
+
+// Get the real reference on standard error, which is the customized error if standard error is embedded
+func (se *StandardError) getStandardReference() IStandardError {
+	if ref, ok := se.getReference().(IStandardError); ok {
+		return ref
+	}
 
 	return se
 }
 
+// Clone the mutable state shared with the original error after a copy
+func (se *StandardError) cloneState() {
+	se.GoError.cloneState()
+	se.infos = se.Infos()
+}
+
 // GetCode gets error code
-func (se *tStandardError) GetCode() int64 {
+func (se *StandardError) GetCode() int64 {
 	return se.code
 }
 
+// GetCodeDefinition gets the definition of the error code, or nil if the code is not registered
+func (se *StandardError) GetCodeDefinition() *CodeDefinition {
+	if se.code == 0 {
+		return nil
+	}
+
+	return LookupCode(se.code)
+}
+
 // DecorateError «decorates» the error passed as "err" parameter.
 // The error returned will be an standard error with additionnal informations and stack trace.
 func DecorateError(err error) IStandardError {
@@ -53,7 +124,7 @@ func DecorateError(err error) IStandardError {
 
 	ierr, ok := err.(IStandardError)
 	if !ok {
-		res := new(tStandardError)
+		res := new(StandardError)
 		_ = res.Init(res, "", nil, err, 1)
 
 		ierr = res
@@ -62,6 +133,24 @@ func DecorateError(err error) IStandardError {
 	return ierr
 }
 
+// DecorateErrorContext is like `DecorateError` but the stack trace is captured according to the debug mode of the
+// context `ctx`
+func DecorateErrorContext(ctx context.Context, err error) IStandardError {
+	if err == nil {
+		return nil
+	}
+
+	ierr, ok := err.(IStandardError)
+	if !ok {
+		res := new(StandardError)
+		_ = res.InitContext(ctx, res, "", nil, err, 1)
+
+		ierr = res
+	}
+
+	return ierr
+}
+
 // DecorateErrorWithDatas is like `DecorateError` with error code and custom data
 func DecorateErrorWithDatas(err error, code int64, data interface{}, msg string, args ...interface{}) IStandardError {
 	if err == nil {
@@ -70,9 +159,14 @@ func DecorateErrorWithDatas(err error, code int64, data interface{}, msg string,
 
 	ierr, ok := err.(IStandardError)
 	if ok {
-		_ = ierr.AddInfo("Recorate for code=%d and message=%s", code, fmt.Sprintf(msg, args...))
+		message := fmt.Sprintf(msg, args...)
+
+		info := makeInfo(InfoRedecoration, fmt.Sprintf("Redecorated for code=%d and message=%s", code, message), 1)
+		info.Code, info.Message = code, message
+
+		ierr.addInfo(info)
 	} else {
-		res := &tStandardError{code: code}
+		res := &StandardError{code: code}
 		_ = res.Init(res, fmt.Sprintf(msg, args...), data, err, 1)
 
 		ierr = res
@@ -83,15 +177,24 @@ func DecorateErrorWithDatas(err error, code int64, data interface{}, msg string,
 
 // MakeError makes an standard error from a message passed as "message" parameter
 func MakeError(message string, args ...interface{}) IStandardError {
-	res := new(tStandardError)
+	res := new(StandardError)
 	_ = res.Init(res, fmt.Sprintf(message, args...), nil, nil, 1)
 
 	return res
 }
 
+// MakeErrorContext is like `MakeError` but the stack trace is captured according to the debug mode of the
+// context `ctx`
+func MakeErrorContext(ctx context.Context, message string, args ...interface{}) IStandardError {
+	res := new(StandardError)
+	_ = res.InitContext(ctx, res, fmt.Sprintf(message, args...), nil, nil, 1)
+
+	return res
+}
+
 // MakeErrorWithDatas is like `MakeError` with error code and custom data
 func MakeErrorWithDatas(code int64, data interface{}, message string, args ...interface{}) IStandardError {
-	res := &tStandardError{code: code}
+	res := &StandardError{code: code}
 	_ = res.Init(res, fmt.Sprintf(message, args...), data, nil, 1)
 
 	return res
@@ -107,8 +210,10 @@ func AddInfo(err error, info string, args ...interface{}) IStandardError {
 		goErr = DecorateError(err)
 	}
 
-	// Delegate call to "AddInfo" method
-	return goErr.AddInfo(info, args...)
+	// Add the information with the call site of this function
+	goErr.addInfo(makeInfo(InfoNote, fmt.Sprintf(info, args...), 1))
+
+	return goErr
 }
 
 // Catch is the global function to catch an error
@@ -148,6 +253,8 @@ func Catch(err *error, catch, finally ErrorHandler) {
 		ierr = DecorateError(resErr)
 	}
 
+	catchHooks.fire(ierr)
+
 	cerr := catch(ierr)
 	if cerr != nil {
 		resErr = cerr
@@ -189,6 +296,8 @@ func Try(try, catch, finally ErrorHandler) (err error) {
 			ierr = DecorateError(err)
 		}
 
+		catchHooks.fire(ierr)
+
 		cerr := catch(ierr)
 		if cerr != nil {
 			err = cerr
@@ -210,10 +319,19 @@ func RaiseWithInfos(errorCode int64, data interface{}, message string, args ...i
 
 // RaiseError is the global function to raise the error passed in argument
 func RaiseError(err error) {
+	raiseError(err, 1)
+}
+
+// Raise the error passed in argument with pruned levels, the error is decorated if it's not an IError
+func raiseError(err error, pruneLevels uint) {
 	gerr, ok := err.(IError)
 	if !ok {
-		gerr = DecorateError(err)
+		res := new(StandardError)
+		_ = res.init(GetDebug(), res, "", nil, err, pruneLevels+1)
+		createHooks.fire(res)
+
+		gerr = res
 	}
 
-	gerr.raise(1)
+	gerr.raise(pruneLevels + 1)
 }
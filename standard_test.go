@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,17 +28,22 @@ func TestAddInfo(t *testing.T) {
 
 	err = AddInfo(err, "%s:%d", id, r)
 
-	rawErr, ok := err.(*tStandardError)
+	rawErr, ok := err.(*StandardError)
 	if !ok {
 		t.Error("Failed on convesion")
 	}
 
-	if rawErr.infos.Len() == 0 {
-		t.Error("Failed on has-infos")
+	infos := rawErr.Infos()
+	if len(infos) == 0 {
+		t.Fatal("Failed on has-infos")
 	}
 
-	if rawErr.infos.String() != fmt.Sprintf("%s:%d\n", id, r) {
-		t.Error("Failed on set-infos:", rawErr.infos.String(), "!=", fmt.Sprintf("%s:%d\n", id, r))
+	if infos[0].Text != fmt.Sprintf("%s:%d", id, r) {
+		t.Error("Failed on set-infos:", infos[0].Text, "!=", fmt.Sprintf("%s:%d", id, r))
+	}
+
+	if !strings.HasSuffix(infos[0].File, "standard_test.go") || (infos[0].Line == 0) || infos[0].Time.IsZero() {
+		t.Error("Failed on info call site:", infos[0])
 	}
 }
 
@@ -83,16 +89,16 @@ func TestMakeErrorNoDebug(t *testing.T) {
 	r := rand.Int63()
 	id := uuid.New()
 
-	err := MakeError("%s:%d", id, r).(*tStandardError)
+	err := MakeError("%s:%d", id, r).(*StandardError)
 	if err.message != fmt.Sprintf("%s:%d", id, r) {
 		t.Error("Failed on MakeError:", err.message, "!=", fmt.Sprintf("%s:%d", id, r))
 	}
 
-	if len(err.trace) != 0 {
+	if err.trace != nil {
 		t.Error("Failed on empty trace")
 	}
 
-	if err.infos.Len() != 0 {
+	if len(err.Infos()) != 0 {
 		t.Error("Failed on no-infos")
 	}
 }
@@ -100,8 +106,8 @@ func TestMakeErrorNoDebug(t *testing.T) {
 func TestMakeErrorDebug(t *testing.T) {
 	SetDebug(true)
 
-	err := MakeError("").(*tStandardError)
-	if len(err.trace) == 0 {
+	err := MakeError("").(*StandardError)
+	if len(err.StackTrace()) == 0 {
 		t.Error("Failed on stack trace")
 	}
 }
@@ -221,3 +227,66 @@ func TestRaiseError(t *testing.T) {
 		RaiseError(fmt.Errorf("error"))
 	}()
 }
+
+type MyStandardError struct {
+	StandardError
+}
+
+func TestStandardErrorName(t *testing.T) {
+	if name := MakeError("").GetName(); name != "github.com/corebreaker/goerrors.StandardError" {
+		t.Error("The standard error should have a qualified name:", name)
+	}
+}
+
+func TestEmbeddedStandardError(t *testing.T) {
+	gerr := &MyStandardError{}
+
+	err := gerr.InitWithCode(gerr, 1234, "message", "data", nil, 0)
+	if err != IStandardError(gerr) {
+		t.Fatal("The initialization should return the customized error")
+	}
+
+	if (err.GetName() != "github.com/corebreaker/goerrors.MyStandardError") || (err.GetCode() != 1234) {
+		t.Error("Bad customized standard error:", err.GetName(), err.GetCode())
+	}
+
+	if (err.GetMessage() != "message") || (err.GetData() != "data") {
+		t.Error("Bad customized standard error:", err.GetMessage(), err.GetData())
+	}
+
+	if _, ok := gerr.InitWithCode(gerr, 1, "other", nil, nil, 0).(*InitError); !ok || (err.GetCode() != 1234) {
+		t.Error("The initialization should be done only once")
+	}
+
+	if (err.AddInfo("info") != err) || (err.WithField("key", 1) != err) || (err.WithFields(nil) != err) {
+		t.Error("The methods of the standard error should return the customized error")
+	}
+
+	if len(err.Infos()) != 1 {
+		t.Error("Bad informations:", err.Infos())
+	}
+
+	if !MakeError("").IsParentOf(err) || !errors.Is(err, &StandardError{}) {
+		t.Error("The standard error should be a parent of the customized error")
+	}
+
+	if DecorateErrorWithDatas(err, 0, nil, "") != err {
+		t.Error("The customized standard error should not be decorated")
+	}
+
+	caught := MakeError("").Try(func(IError) error {
+		err.Raise()
+
+		return nil
+	}, func(err IError) error {
+		return err
+	}, nil)
+
+	if serr, ok := caught.(*MyStandardError); !ok || (serr.GetCode() != 1234) || (serr.GetMessage() != "message") {
+		t.Error("The customized standard error should be caught by a standard error:", caught)
+	}
+
+	if (&StandardError{}).AddInfo("info") == nil {
+		t.Error("An uninitialized standard error should be returned")
+	}
+}
@@ -0,0 +1,27 @@
+package goerrors
+
+// TryValue is like the global function `Try` for a try block which returns a value.
+// The value returned by the try block is returned with the error, the panic/recover semantics are the ones of `Try`.
+func TryValue[T any](try func() (T, error), catch, finally ErrorHandler) (T, error) {
+	var res T
+
+	err := Try(func(IError) error {
+		var err error
+
+		res, err = try()
+
+		return err
+	}, catch, finally)
+
+	return res, err
+}
+
+// Must returns the value `value` if the error `err` is nil, otherwise it raises the error.
+// It's used to call functions which return a value with an error, like that: `f := Must(os.Open(name))`.
+func Must[T any](value T, err error) T {
+	if err != nil {
+		raiseError(err, 1)
+	}
+
+	return value
+}
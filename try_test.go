@@ -0,0 +1,90 @@
+package goerrors
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTryValue(t *testing.T) {
+	value, err := TryValue(func() (int, error) {
+		return 12, nil
+	}, nil, nil)
+
+	if (value != 12) || (err != nil) {
+		t.Error("The value of the try block should be returned:", value, err)
+	}
+
+	value, err = TryValue(func() (int, error) {
+		return 12, errors.New("error")
+	}, func(err IError) error {
+		return err
+	}, nil)
+
+	if (value != 12) || (err == nil) {
+		t.Error("The value and the error of the try block should be returned:", value, err)
+	}
+
+	finallyCalled := false
+
+	value, err = TryValue(func() (int, error) {
+		Raise("raised")
+
+		return 12, nil
+	}, func(err IError) error {
+		return err
+	}, func(err IError) error {
+		finallyCalled = true
+
+		return err
+	})
+
+	if (value != 0) || (err == nil) || (err.(IError).GetMessage() != "raised") || !finallyCalled {
+		t.Error("The raised error should be caught:", value, err)
+	}
+}
+
+func TestTryValueWithoutCatch(t *testing.T) {
+	tryErr := Try(func(IError) error {
+		Raise("raised")
+
+		return nil
+	}, nil, nil)
+
+	value, err := TryValue(func() (int, error) {
+		Raise("raised")
+
+		return 12, nil
+	}, nil, nil)
+
+	if (value != 0) || (err != tryErr) {
+		t.Error("Without catch block, a raised error should be processed like with Try:", value, err, tryErr)
+	}
+}
+
+func TestMust(t *testing.T) {
+	if Must(12, nil) != 12 {
+		t.Error("The value should be returned without error")
+	}
+
+	SetDebug(true)
+	defer SetDebug(false)
+
+	src := errors.New("error")
+
+	err := Try(func(IError) error {
+		Must(12, src)
+
+		return nil
+	}, func(err IError) error {
+		return err
+	}, nil)
+
+	ierr, ok := err.(IError)
+	if !ok || (ierr.GetSource() != src) {
+		t.Fatal("The error should be raised:", err)
+	}
+
+	if trace := ierr.StackTrace(); trace[0].Function != "TestMust.func1" {
+		t.Error("The stack trace should start at the call of Must:", trace[0])
+	}
+}
@@ -2,6 +2,7 @@ package goerrors
 
 import (
 	"reflect"
+	"strings"
 )
 
 // Concatenate 2 string lists
@@ -59,3 +60,43 @@ func _getTypeHierarchy(thisType, finalType reflect.Type) []string {
 
 	return _concat(res, list)
 }
+
+// Find in the structure value `value` the embedded field which has the type `embeddedType`.
+// It returns an invalid value if there is no such field.
+func _findEmbedded(value reflect.Value, embeddedType reflect.Type) reflect.Value {
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	n := value.NumField()
+	for i := 0; i < n; i++ {
+		if !value.Type().Field(i).Anonymous {
+			continue
+		}
+
+		field := value.Field(i)
+		if field.Type() == embeddedType {
+			return field
+		}
+
+		if res := _findEmbedded(field, embeddedType); res.IsValid() {
+			return res
+		}
+	}
+
+	return reflect.Value{}
+}
+
+// Split a qualified function name (as given by the runtime) into its package path and its function name.
+func _splitFunctionName(name string) (string, string) {
+	start := strings.LastIndex(name, "/") + 1
+
+	dot := strings.Index(name[start:], ".")
+	if dot < 0 {
+		return "", name
+	}
+
+	dot += start
+
+	return name[:dot], name[dot+1:]
+}
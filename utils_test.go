@@ -192,3 +192,35 @@ func TestStructHierarchyFourLevels(t *testing.T) {
 			res)
 	}
 }
+
+func TestFindEmbedded(t *testing.T) {
+	e := E{}
+
+	if res := _findEmbedded(reflect.ValueOf(&e).Elem(), tB); !res.IsValid() || (res.Addr().Interface() != &e.D.B) {
+		t.Error("The first embedded field with the searched type should be found")
+	}
+
+	if _findEmbedded(reflect.ValueOf(&e).Elem(), reflect.TypeOf(0.0)).IsValid() {
+		t.Error("A type which is not embedded should not be found")
+	}
+
+	if _findEmbedded(reflect.ValueOf(0), tA).IsValid() {
+		t.Error("Nothing should be found in a non-structure value")
+	}
+}
+
+func TestSplitFunctionName(t *testing.T) {
+	cases := [][3]string{
+		{"github.com/corebreaker/goerrors.(*GoError).Init", "github.com/corebreaker/goerrors", "(*GoError).Init"},
+		{"main.main", "main", "main"},
+		{"github.com/a/b.Func.func1", "github.com/a/b", "Func.func1"},
+		{"noPackage", "", "noPackage"},
+	}
+
+	for _, c := range cases {
+		pkg, function := _splitFunctionName(c[0])
+		if (pkg != c[1]) || (function != c[2]) {
+			t.Errorf("Bad split of %s: %s, %s", c[0], pkg, function)
+		}
+	}
+}